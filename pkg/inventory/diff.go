@@ -0,0 +1,309 @@
+package inventory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ChangeType describes how an inventory item changed.
+type ChangeType string
+
+// Change types.
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// Change categories.
+const (
+	CategoryHost      = "host"
+	CategoryKernel    = "kernel"
+	CategoryOS        = "os"
+	CategoryArch      = "architecture"
+	CategoryFqdn      = "fqdn"
+	CategoryInterface = "interface"
+	CategoryRoute     = "route"
+	CategoryLabel     = "label"
+)
+
+// Change is a single difference between two snapshots.
+type Change struct {
+	Hostname string
+	Type     ChangeType
+	Category string
+	// Key identifies the item within the category: the interface name,
+	// route destination, or label key. Empty for host-level fields.
+	Key string
+	Old string
+	New string
+}
+
+// Report lists the changes between two snapshots.
+type Report struct {
+	From    time.Time
+	To      time.Time
+	Changes []Change
+}
+
+// Diff compares two snapshots. Changes are ordered by hostname, then
+// category, then key.
+func Diff(
+	from *Snapshot,
+	to *Snapshot,
+) *Report {
+	report := &Report{From: from.TakenAt, To: to.TakenAt}
+
+	before := hostsByName(from)
+	after := hostsByName(to)
+
+	names := slices.Collect(maps.Keys(before))
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		old, existed := before[name]
+		cur, exists := after[name]
+
+		switch {
+		case !existed:
+			report.add(name, ChangeAdded, CategoryHost, "", "", describeHost(cur))
+		case !exists:
+			report.add(name, ChangeRemoved, CategoryHost, "", describeHost(old), "")
+		default:
+			report.diffHost(old, cur)
+		}
+	}
+
+	return report
+}
+
+// HasChanges reports whether the snapshots differ.
+func (r *Report) HasChanges() bool {
+	return len(r.Changes) > 0
+}
+
+// Hosts returns the hostnames with at least one change, sorted.
+func (r *Report) Hosts() []string {
+	var hosts []string
+
+	for _, c := range r.Changes {
+		if len(hosts) == 0 || hosts[len(hosts)-1] != c.Hostname {
+			hosts = append(hosts, c.Hostname)
+		}
+	}
+
+	return hosts
+}
+
+// String renders the report for humans, one section per host.
+func (r *Report) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(
+		&b,
+		"Inventory changes %s -> %s\n",
+		r.From.Format(time.RFC3339),
+		r.To.Format(time.RFC3339),
+	)
+
+	if !r.HasChanges() {
+		b.WriteString("No changes.\n")
+
+		return b.String()
+	}
+
+	host := ""
+
+	for _, c := range r.Changes {
+		if c.Category == CategoryHost {
+			mark := "+"
+			if c.Type == ChangeRemoved {
+				mark = "-"
+			}
+
+			detail := joinNonEmpty(string(c.Type), c.Old, c.New)
+			fmt.Fprintf(&b, "%s %s (%s)\n", mark, c.Hostname, detail)
+			host = ""
+
+			continue
+		}
+
+		if c.Hostname != host {
+			fmt.Fprintf(&b, "~ %s\n", c.Hostname)
+			host = c.Hostname
+		}
+
+		fmt.Fprintf(&b, "    %s\n", describeChange(c))
+	}
+
+	return b.String()
+}
+
+// add appends a change to the report.
+func (r *Report) add(
+	hostname string,
+	t ChangeType,
+	category string,
+	key string,
+	old string,
+	cur string,
+) {
+	r.Changes = append(r.Changes, Change{
+		Hostname: hostname,
+		Type:     t,
+		Category: category,
+		Key:      key,
+		Old:      old,
+		New:      cur,
+	})
+}
+
+// diffHost records the changes between two versions of a host.
+func (r *Report) diffHost(
+	old Host,
+	cur Host,
+) {
+	name := cur.Hostname
+
+	scalar := func(category, a, b string) {
+		if a != b {
+			r.add(name, ChangeModified, category, "", a, b)
+		}
+	}
+
+	scalar(CategoryArch, old.Architecture, cur.Architecture)
+	scalar(CategoryFqdn, old.Fqdn, cur.Fqdn)
+	r.diffItems(name, CategoryInterface, interfaceItems(old), interfaceItems(cur))
+	scalar(CategoryKernel, old.KernelVersion, cur.KernelVersion)
+	r.diffItems(name, CategoryLabel, old.Labels, cur.Labels)
+	scalar(CategoryOS, osString(old), osString(cur))
+	r.diffItems(name, CategoryRoute, routeItems(old), routeItems(cur))
+}
+
+// diffItems records added, removed, and modified keyed items.
+func (r *Report) diffItems(
+	hostname string,
+	category string,
+	old map[string]string,
+	cur map[string]string,
+) {
+	keys := slices.Collect(maps.Keys(old))
+	for k := range cur {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		a, hadOld := old[k]
+		b, hasNew := cur[k]
+
+		switch {
+		case !hadOld:
+			r.add(hostname, ChangeAdded, category, k, "", b)
+		case !hasNew:
+			r.add(hostname, ChangeRemoved, category, k, a, "")
+		case a != b:
+			r.add(hostname, ChangeModified, category, k, a, b)
+		}
+	}
+}
+
+// hostsByName indexes a snapshot's hosts by hostname.
+func hostsByName(
+	s *Snapshot,
+) map[string]Host {
+	m := make(map[string]Host, len(s.Hosts))
+	for _, h := range s.Hosts {
+		m[h.Hostname] = h
+	}
+
+	return m
+}
+
+// interfaceItems describes each interface keyed by name.
+func interfaceItems(
+	h Host,
+) map[string]string {
+	m := make(map[string]string, len(h.Interfaces))
+	for _, i := range h.Interfaces {
+		m[i.Name] = joinNonEmpty(i.IPv4, i.IPv6, i.MAC)
+	}
+
+	return m
+}
+
+// routeItems describes each route keyed by destination, mask, and
+// interface.
+func routeItems(
+	h Host,
+) map[string]string {
+	m := make(map[string]string, len(h.Routes))
+	for _, rt := range h.Routes {
+		key := rt.Destination
+		if rt.Mask != "" {
+			key += "/" + rt.Mask
+		}
+
+		if rt.Interface != "" {
+			key += " dev " + rt.Interface
+		}
+
+		m[key] = fmt.Sprintf("via %s metric %d", rt.Gateway, rt.Metric)
+	}
+
+	return m
+}
+
+// osString describes a host's operating system.
+func osString(
+	h Host,
+) string {
+	return joinNonEmpty(h.Distribution, h.OSVersion)
+}
+
+// describeHost summarizes a host for added and removed changes.
+func describeHost(
+	h Host,
+) string {
+	return joinNonEmpty(osString(h), h.KernelVersion)
+}
+
+// describeChange renders a non-host change as one line.
+func describeChange(
+	c Change,
+) string {
+	label := c.Category
+	if c.Key != "" {
+		label += " " + c.Key
+	}
+
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("%s: added (%s)", label, c.New)
+	case ChangeRemoved:
+		return fmt.Sprintf("%s: removed (%s)", label, c.Old)
+	default:
+		return fmt.Sprintf("%s: %s -> %s", label, c.Old, c.New)
+	}
+}
+
+// joinNonEmpty joins the non-empty parts with a space.
+func joinNonEmpty(
+	parts ...string,
+) string {
+	return strings.Join(slices.DeleteFunc(parts, func(p string) bool {
+		return p == ""
+	}), " ")
+}
@@ -0,0 +1,178 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/inventory"
+)
+
+type DiffPublicTestSuite struct {
+	suite.Suite
+}
+
+func TestDiffPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(DiffPublicTestSuite))
+}
+
+func (s *DiffPublicTestSuite) snapshots() (*inventory.Snapshot, *inventory.Snapshot) {
+	from := &inventory.Snapshot{
+		TakenAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Hosts: []inventory.Host{
+			{
+				Hostname:      "db-01",
+				KernelVersion: "5.15.0",
+			},
+			{
+				Hostname:      "web-01",
+				KernelVersion: "5.15.0",
+				Distribution:  "Ubuntu",
+				OSVersion:     "22.04",
+				Labels:        map[string]string{"env": "staging", "old": "x"},
+				Interfaces: []inventory.Interface{
+					{Name: "eth0", IPv4: "10.0.0.1"},
+				},
+				Routes: []inventory.Route{
+					{Destination: "0.0.0.0", Gateway: "10.0.0.254", Interface: "eth0"},
+				},
+			},
+		},
+	}
+
+	to := &inventory.Snapshot{
+		TakenAt: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		Hosts: []inventory.Host{
+			{
+				Hostname:      "web-01",
+				KernelVersion: "6.1.0",
+				Distribution:  "Ubuntu",
+				OSVersion:     "24.04",
+				Labels:        map[string]string{"env": "prod"},
+				Interfaces: []inventory.Interface{
+					{Name: "eth0", IPv4: "10.0.0.1"},
+					{Name: "eth1", IPv4: "192.168.1.5"},
+				},
+				Routes: []inventory.Route{
+					{Destination: "0.0.0.0", Gateway: "10.0.0.1", Interface: "eth0"},
+				},
+			},
+			{
+				Hostname:      "web-02",
+				KernelVersion: "6.1.0",
+			},
+		},
+	}
+
+	return from, to
+}
+
+func (s *DiffPublicTestSuite) TestDiff() {
+	from, to := s.snapshots()
+
+	report := inventory.Diff(from, to)
+
+	s.True(report.HasChanges())
+	s.Equal([]string{"db-01", "web-01", "web-02"}, report.Hosts())
+	s.Equal([]inventory.Change{
+		{
+			Hostname: "db-01",
+			Type:     inventory.ChangeRemoved,
+			Category: inventory.CategoryHost,
+			Old:      "5.15.0",
+		},
+		{
+			Hostname: "web-01",
+			Type:     inventory.ChangeAdded,
+			Category: inventory.CategoryInterface,
+			Key:      "eth1",
+			New:      "192.168.1.5",
+		},
+		{
+			Hostname: "web-01",
+			Type:     inventory.ChangeModified,
+			Category: inventory.CategoryKernel,
+			Old:      "5.15.0",
+			New:      "6.1.0",
+		},
+		{
+			Hostname: "web-01",
+			Type:     inventory.ChangeModified,
+			Category: inventory.CategoryLabel,
+			Key:      "env",
+			Old:      "staging",
+			New:      "prod",
+		},
+		{
+			Hostname: "web-01",
+			Type:     inventory.ChangeRemoved,
+			Category: inventory.CategoryLabel,
+			Key:      "old",
+			Old:      "x",
+		},
+		{
+			Hostname: "web-01",
+			Type:     inventory.ChangeModified,
+			Category: inventory.CategoryOS,
+			Old:      "Ubuntu 22.04",
+			New:      "Ubuntu 24.04",
+		},
+		{
+			Hostname: "web-01",
+			Type:     inventory.ChangeModified,
+			Category: inventory.CategoryRoute,
+			Key:      "0.0.0.0 dev eth0",
+			Old:      "via 10.0.0.254 metric 0",
+			New:      "via 10.0.0.1 metric 0",
+		},
+		{
+			Hostname: "web-02",
+			Type:     inventory.ChangeAdded,
+			Category: inventory.CategoryHost,
+			New:      "6.1.0",
+		},
+	}, report.Changes)
+}
+
+func (s *DiffPublicTestSuite) TestReportString() {
+	tests := []struct {
+		name string
+		same bool
+		want string
+	}{
+		{
+			name: "renders changes grouped by host",
+			want: `Inventory changes 2026-03-01T00:00:00Z -> 2026-03-02T00:00:00Z
+- db-01 (removed 5.15.0)
+~ web-01
+    interface eth1: added (192.168.1.5)
+    kernel: 5.15.0 -> 6.1.0
+    label env: staging -> prod
+    label old: removed (x)
+    os: Ubuntu 22.04 -> Ubuntu 24.04
+    route 0.0.0.0 dev eth0: via 10.0.0.254 metric 0 -> via 10.0.0.1 metric 0
++ web-02 (added 6.1.0)
+`,
+		},
+		{
+			name: "renders no changes",
+			same: true,
+			want: `Inventory changes 2026-03-01T00:00:00Z -> 2026-03-01T00:00:00Z
+No changes.
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			from, to := s.snapshots()
+			if tc.same {
+				to = from
+			}
+
+			report := inventory.Diff(from, to)
+			s.Equal(tc.want, report.String())
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package inventory
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+// ExportOption configures an inventory exporter.
+type ExportOption func(*exportOptions)
+
+type exportOptions struct {
+	groupLabels []string
+	port        int
+}
+
+// WithGroupLabels limits the labels used to build groups to the given
+// keys; with no keys, no groups are built. By default every label
+// becomes a group.
+func WithGroupLabels(
+	keys ...string,
+) ExportOption {
+	return func(o *exportOptions) {
+		o.groupLabels = append([]string{}, keys...)
+	}
+}
+
+// WithPort sets the port appended to Prometheus targets. Zero, the
+// default, emits bare addresses.
+func WithPort(
+	port int,
+) ExportOption {
+	return func(o *exportOptions) {
+		o.port = port
+	}
+}
+
+// AnsibleYAML writes a YAML inventory. Every agent is listed under
+// all.hosts with ansible_host set to its primary address, and each
+// label becomes a child group named "<key>_<value>".
+func AnsibleYAML(
+	w io.Writer,
+	agents []osapi.Agent,
+	opts ...ExportOption,
+) error {
+	options := newExportOptions(opts)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "all:")
+	fmt.Fprintln(bw, "  hosts:")
+
+	for _, a := range sortedAgents(agents) {
+		addr := ansibleHost(a)
+		if addr == "" {
+			fmt.Fprintf(bw, "    %s: {}\n", strconv.Quote(a.Hostname))
+
+			continue
+		}
+
+		fmt.Fprintf(bw, "    %s:\n", strconv.Quote(a.Hostname))
+		fmt.Fprintf(bw, "      ansible_host: %s\n", strconv.Quote(addr))
+	}
+
+	groups := labelGroups(agents, options)
+	if len(groups) > 0 {
+		fmt.Fprintln(bw, "  children:")
+
+		for _, name := range slices.Sorted(maps.Keys(groups)) {
+			fmt.Fprintf(bw, "    %s:\n", name)
+			fmt.Fprintln(bw, "      hosts:")
+
+			for _, h := range groups[name] {
+				fmt.Fprintf(bw, "        %s: {}\n", strconv.Quote(h))
+			}
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write ansible inventory: %w", err)
+	}
+
+	return nil
+}
+
+// AnsibleINI writes an INI inventory with the same layout as
+// AnsibleYAML: an [all] section with host variables followed by one
+// section per label group.
+func AnsibleINI(
+	w io.Writer,
+	agents []osapi.Agent,
+	opts ...ExportOption,
+) error {
+	options := newExportOptions(opts)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "[all]")
+
+	for _, a := range sortedAgents(agents) {
+		if addr := ansibleHost(a); addr != "" {
+			fmt.Fprintf(bw, "%s ansible_host=%s\n", a.Hostname, addr)
+		} else {
+			fmt.Fprintln(bw, a.Hostname)
+		}
+	}
+
+	groups := labelGroups(agents, options)
+	for _, name := range slices.Sorted(maps.Keys(groups)) {
+		fmt.Fprintf(bw, "\n[%s]\n", name)
+
+		for _, h := range groups[name] {
+			fmt.Fprintln(bw, h)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write ansible inventory: %w", err)
+	}
+
+	return nil
+}
+
+// TargetGroup is a Prometheus file_sd target group.
+type TargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// PrometheusTargets groups agents with identical labels into file_sd
+// target groups. Targets use the primary address, falling back to the
+// FQDN and then the hostname. Label keys are sanitized to valid
+// Prometheus label names.
+func PrometheusTargets(
+	agents []osapi.Agent,
+	opts ...ExportOption,
+) []TargetGroup {
+	options := newExportOptions(opts)
+
+	var (
+		order  []string
+		groups = make(map[string]*TargetGroup)
+	)
+
+	for _, a := range sortedAgents(agents) {
+		labels := make(map[string]string)
+		for _, k := range groupKeys(a, options) {
+			labels[sanitizeName(k)] = a.Labels[k]
+		}
+
+		key := fmt.Sprint(labels)
+
+		g, ok := groups[key]
+		if !ok {
+			g = &TargetGroup{Targets: []string{}}
+			if len(labels) > 0 {
+				g.Labels = labels
+			}
+
+			groups[key] = g
+			order = append(order, key)
+		}
+
+		g.Targets = append(g.Targets, promTarget(a, options.port))
+	}
+
+	out := make([]TargetGroup, 0, len(order))
+	for _, key := range order {
+		out = append(out, *groups[key])
+	}
+
+	return out
+}
+
+// PrometheusFileSD writes PrometheusTargets as file_sd JSON.
+func PrometheusFileSD(
+	w io.Writer,
+	agents []osapi.Agent,
+	opts ...ExportOption,
+) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(PrometheusTargets(agents, opts...)); err != nil {
+		return fmt.Errorf("write prometheus file_sd: %w", err)
+	}
+
+	return nil
+}
+
+// EtcHosts writes an /etc/hosts style listing mapping each agent's
+// primary address to its FQDN and hostname. Agents without a primary
+// address are written as comments.
+func EtcHosts(
+	w io.Writer,
+	agents []osapi.Agent,
+) error {
+	bw := bufio.NewWriter(w)
+
+	for _, a := range sortedAgents(agents) {
+		names := joinNonEmpty(a.Fqdn, a.Hostname)
+		if a.Fqdn == a.Hostname {
+			names = a.Hostname
+		}
+
+		addr := PrimaryAddress(a)
+		if addr == "" {
+			fmt.Fprintf(bw, "# %s: no primary address\n", a.Hostname)
+
+			continue
+		}
+
+		fmt.Fprintf(bw, "%s\t%s\n", addr, names)
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write hosts file: %w", err)
+	}
+
+	return nil
+}
+
+// PrimaryAddress returns the IPv4 address of the agent's primary
+// interface, or its IPv6 address if it has no IPv4 address. It returns
+// an empty string if the primary interface is unknown.
+func PrimaryAddress(
+	a osapi.Agent,
+) string {
+	for _, i := range a.Interfaces {
+		if i.Name != a.PrimaryInterface {
+			continue
+		}
+
+		if i.IPv4 != "" {
+			return stripPrefix(i.IPv4)
+		}
+
+		return stripPrefix(i.IPv6)
+	}
+
+	return ""
+}
+
+// newExportOptions applies opts over the defaults.
+func newExportOptions(
+	opts []ExportOption,
+) exportOptions {
+	var options exportOptions
+
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return options
+}
+
+// sortedAgents returns a copy of agents sorted by hostname.
+func sortedAgents(
+	agents []osapi.Agent,
+) []osapi.Agent {
+	sorted := slices.Clone(agents)
+	slices.SortFunc(sorted, func(a, b osapi.Agent) int {
+		return strings.Compare(a.Hostname, b.Hostname)
+	})
+
+	return sorted
+}
+
+// groupKeys returns the agent's label keys used for grouping, sorted.
+func groupKeys(
+	a osapi.Agent,
+	options exportOptions,
+) []string {
+	var keys []string
+
+	for k := range a.Labels {
+		if options.groupLabels == nil || slices.Contains(options.groupLabels, k) {
+			keys = append(keys, k)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// labelGroups maps "<key>_<value>" group names to sorted hostnames.
+func labelGroups(
+	agents []osapi.Agent,
+	options exportOptions,
+) map[string][]string {
+	groups := make(map[string][]string)
+
+	for _, a := range sortedAgents(agents) {
+		for _, k := range groupKeys(a, options) {
+			name := sanitizeName(k + "_" + a.Labels[k])
+			groups[name] = append(groups[name], a.Hostname)
+		}
+	}
+
+	return groups
+}
+
+// ansibleHost returns the address Ansible should connect to.
+func ansibleHost(
+	a osapi.Agent,
+) string {
+	if addr := PrimaryAddress(a); addr != "" {
+		return addr
+	}
+
+	return a.Fqdn
+}
+
+// promTarget returns the scrape target for an agent.
+func promTarget(
+	a osapi.Agent,
+	port int,
+) string {
+	host := ansibleHost(a)
+	if host == "" {
+		host = a.Hostname
+	}
+
+	if port == 0 {
+		if strings.Contains(host, ":") {
+			return "[" + host + "]"
+		}
+
+		return host
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// stripPrefix removes a CIDR prefix length from an address.
+func stripPrefix(
+	addr string,
+) string {
+	ip, _, _ := strings.Cut(addr, "/")
+
+	return ip
+}
+
+// sanitizeName replaces characters that are invalid in Ansible group
+// and Prometheus label names with underscores.
+func sanitizeName(
+	name string,
+) string {
+	var b strings.Builder
+
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,335 @@
+package inventory_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/inventory"
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type ExportPublicTestSuite struct {
+	suite.Suite
+}
+
+func TestExportPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportPublicTestSuite))
+}
+
+// errWriter fails every write.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// writerOr returns w, or fallback when w is nil.
+func writerOr(
+	w io.Writer,
+	fallback io.Writer,
+) io.Writer {
+	if w != nil {
+		return w
+	}
+
+	return fallback
+}
+
+func (s *ExportPublicTestSuite) agents() []osapi.Agent {
+	return []osapi.Agent{
+		{
+			Hostname:         "web-02",
+			Fqdn:             "web-02.example.com",
+			PrimaryInterface: "eth0",
+			Labels:           map[string]string{"group": "web", "env": "prod"},
+			Interfaces: []osapi.NetworkInterface{
+				{Name: "lo", IPv4: "127.0.0.1"},
+				{Name: "eth0", IPv4: "10.0.0.2/24"},
+			},
+		},
+		{
+			Hostname:         "web-01",
+			Fqdn:             "web-01.example.com",
+			PrimaryInterface: "eth0",
+			Labels:           map[string]string{"group": "web", "env": "prod"},
+			Interfaces: []osapi.NetworkInterface{
+				{Name: "eth0", IPv4: "10.0.0.1"},
+			},
+		},
+		{
+			Hostname:         "db-01",
+			PrimaryInterface: "eth0",
+			Labels:           map[string]string{"group": "db", "env": "prod"},
+			Interfaces: []osapi.NetworkInterface{
+				{Name: "eth0", IPv6: "fd00::1"},
+			},
+		},
+		{
+			Hostname: "edge-01",
+			Fqdn:     "edge-01",
+			Labels:   map[string]string{"9zone": "us-east.1"},
+		},
+	}
+}
+
+func (s *ExportPublicTestSuite) TestAnsibleYAML() {
+	tests := []struct {
+		name    string
+		opts    []inventory.ExportOption
+		writer  io.Writer
+		want    string
+		wantErr string
+	}{
+		{
+			name: "groups by every label",
+			want: `all:
+  hosts:
+    "db-01":
+      ansible_host: "fd00::1"
+    "edge-01":
+      ansible_host: "edge-01"
+    "web-01":
+      ansible_host: "10.0.0.1"
+    "web-02":
+      ansible_host: "10.0.0.2"
+  children:
+    _9zone_us_east_1:
+      hosts:
+        "edge-01": {}
+    env_prod:
+      hosts:
+        "db-01": {}
+        "web-01": {}
+        "web-02": {}
+    group_db:
+      hosts:
+        "db-01": {}
+    group_web:
+      hosts:
+        "web-01": {}
+        "web-02": {}
+`,
+		},
+		{
+			name: "groups by selected labels",
+			opts: []inventory.ExportOption{inventory.WithGroupLabels("missing")},
+			want: `all:
+  hosts:
+    "db-01":
+      ansible_host: "fd00::1"
+    "edge-01":
+      ansible_host: "edge-01"
+    "web-01":
+      ansible_host: "10.0.0.1"
+    "web-02":
+      ansible_host: "10.0.0.2"
+`,
+		},
+		{
+			name:    "write error is wrapped",
+			writer:  errWriter{},
+			wantErr: "write ansible inventory",
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			var buf bytes.Buffer
+
+			err := inventory.AnsibleYAML(writerOr(tc.writer, &buf), s.agents(), tc.opts...)
+			if tc.wantErr != "" {
+				s.ErrorContains(err, tc.wantErr)
+				s.ErrorContains(err, "disk full")
+
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(tc.want, buf.String())
+		})
+	}
+}
+
+func (s *ExportPublicTestSuite) TestAnsibleINI() {
+	tests := []struct {
+		name    string
+		writer  io.Writer
+		want    string
+		wantErr string
+	}{
+		{
+			name: "groups by selected labels",
+			want: `[all]
+bare
+db-01 ansible_host=fd00::1
+edge-01 ansible_host=edge-01
+web-01 ansible_host=10.0.0.1
+web-02 ansible_host=10.0.0.2
+
+[group_db]
+db-01
+
+[group_web]
+web-01
+web-02
+`,
+		},
+		{
+			name:    "write error is wrapped",
+			writer:  errWriter{},
+			wantErr: "write ansible inventory",
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			var buf bytes.Buffer
+
+			err := inventory.AnsibleINI(
+				writerOr(tc.writer, &buf),
+				append(s.agents(), osapi.Agent{Hostname: "bare"}),
+				inventory.WithGroupLabels("group"),
+			)
+			if tc.wantErr != "" {
+				s.ErrorContains(err, tc.wantErr)
+				s.ErrorContains(err, "disk full")
+
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(tc.want, buf.String())
+		})
+	}
+}
+
+func (s *ExportPublicTestSuite) TestPrometheusTargets() {
+	tests := []struct {
+		name string
+		opts []inventory.ExportOption
+		want []inventory.TargetGroup
+	}{
+		{
+			name: "groups hosts with identical labels",
+			opts: []inventory.ExportOption{
+				inventory.WithGroupLabels("group"),
+				inventory.WithPort(9100),
+			},
+			want: []inventory.TargetGroup{
+				{
+					Targets: []string{"[fd00::1]:9100"},
+					Labels:  map[string]string{"group": "db"},
+				},
+				{
+					Targets: []string{"edge-01:9100"},
+				},
+				{
+					Targets: []string{"10.0.0.1:9100", "10.0.0.2:9100"},
+					Labels:  map[string]string{"group": "web"},
+				},
+			},
+		},
+		{
+			name: "emits bare addresses without a port",
+			opts: []inventory.ExportOption{inventory.WithGroupLabels()},
+			want: []inventory.TargetGroup{
+				{
+					Targets: []string{"[fd00::1]", "edge-01", "10.0.0.1", "10.0.0.2"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.Equal(tc.want, inventory.PrometheusTargets(s.agents(), tc.opts...))
+		})
+	}
+}
+
+func (s *ExportPublicTestSuite) TestPrometheusFileSD() {
+	tests := []struct {
+		name    string
+		writer  io.Writer
+		want    string
+		wantErr string
+	}{
+		{
+			name: "writes target groups as JSON",
+			want: `[{"targets":["10.0.0.2:9100"],"labels":{"env":"prod","group":"web"}}]`,
+		},
+		{
+			name:    "write error is wrapped",
+			writer:  errWriter{},
+			wantErr: "write prometheus file_sd",
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			var buf bytes.Buffer
+
+			err := inventory.PrometheusFileSD(
+				writerOr(tc.writer, &buf),
+				s.agents()[:1],
+				inventory.WithPort(9100),
+			)
+			if tc.wantErr != "" {
+				s.ErrorContains(err, tc.wantErr)
+				s.ErrorContains(err, "disk full")
+
+				return
+			}
+
+			s.Require().NoError(err)
+			s.JSONEq(tc.want, buf.String())
+		})
+	}
+}
+
+func (s *ExportPublicTestSuite) TestEtcHosts() {
+	tests := []struct {
+		name    string
+		writer  io.Writer
+		want    string
+		wantErr string
+	}{
+		{
+			name: "writes primary addresses and notes hosts without one",
+			want: "# bare: no primary address\n" +
+				"fd00::1\tdb-01\n" +
+				"# edge-01: no primary address\n" +
+				"10.0.0.1\tweb-01.example.com web-01\n" +
+				"10.0.0.2\tweb-02.example.com web-02\n",
+		},
+		{
+			name:    "write error is wrapped",
+			writer:  errWriter{},
+			wantErr: "write hosts file",
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			var buf bytes.Buffer
+
+			err := inventory.EtcHosts(
+				writerOr(tc.writer, &buf),
+				append(s.agents(), osapi.Agent{Hostname: "bare"}),
+			)
+			if tc.wantErr != "" {
+				s.ErrorContains(err, tc.wantErr)
+				s.ErrorContains(err, "disk full")
+
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(tc.want, buf.String())
+		})
+	}
+}
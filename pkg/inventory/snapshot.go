@@ -0,0 +1,177 @@
+// Package inventory captures fleet state from OSAPI agents, compares
+// snapshots over time, and exports it to other inventory formats.
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+// Snapshot is the state of every agent at a point in time.
+type Snapshot struct {
+	TakenAt time.Time `json:"taken_at"`
+	Hosts   []Host    `json:"hosts"`
+}
+
+// Host is the inventory view of an agent. Volatile data such as load,
+// memory usage, and uptime is omitted so snapshots diff cleanly.
+type Host struct {
+	Hostname         string            `json:"hostname"`
+	Fqdn             string            `json:"fqdn,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	State            string            `json:"state,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Architecture     string            `json:"architecture,omitempty"`
+	CPUCount         int               `json:"cpu_count,omitempty"`
+	MemoryTotal      int               `json:"memory_total,omitempty"`
+	KernelVersion    string            `json:"kernel_version,omitempty"`
+	Distribution     string            `json:"distribution,omitempty"`
+	OSVersion        string            `json:"os_version,omitempty"`
+	PackageMgr       string            `json:"package_mgr,omitempty"`
+	ServiceMgr       string            `json:"service_mgr,omitempty"`
+	PrimaryInterface string            `json:"primary_interface,omitempty"`
+	Interfaces       []Interface       `json:"interfaces,omitempty"`
+	Routes           []Route           `json:"routes,omitempty"`
+	Facts            map[string]any    `json:"facts,omitempty"`
+}
+
+// Interface is a network interface on a host.
+type Interface struct {
+	Name   string `json:"name"`
+	Family string `json:"family,omitempty"`
+	IPv4   string `json:"ipv4,omitempty"`
+	IPv6   string `json:"ipv6,omitempty"`
+	MAC    string `json:"mac,omitempty"`
+}
+
+// Route is a routing table entry on a host.
+type Route struct {
+	Destination string `json:"destination"`
+	Mask        string `json:"mask,omitempty"`
+	Gateway     string `json:"gateway,omitempty"`
+	Interface   string `json:"interface,omitempty"`
+	Flags       string `json:"flags,omitempty"`
+	Metric      int    `json:"metric,omitempty"`
+}
+
+// Capture lists all agents and returns them as a snapshot.
+func Capture(
+	ctx context.Context,
+	client *osapi.Client,
+) (*Snapshot, error) {
+	resp, err := client.Agent.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("capture inventory: %w", err)
+	}
+
+	return FromAgents(resp.Data.Agents, time.Now().UTC()), nil
+}
+
+// FromAgents builds a snapshot from agents, sorted by hostname.
+func FromAgents(
+	agents []osapi.Agent,
+	takenAt time.Time,
+) *Snapshot {
+	hosts := make([]Host, 0, len(agents))
+	for _, a := range agents {
+		hosts = append(hosts, hostFromAgent(a))
+	}
+
+	slices.SortFunc(hosts, func(a, b Host) int {
+		return strings.Compare(a.Hostname, b.Hostname)
+	})
+
+	return &Snapshot{TakenAt: takenAt, Hosts: hosts}
+}
+
+// Host returns the host with the given hostname.
+func (s *Snapshot) Host(
+	hostname string,
+) (Host, bool) {
+	for _, h := range s.Hosts {
+		if h.Hostname == hostname {
+			return h, true
+		}
+	}
+
+	return Host{}, false
+}
+
+// WriteJSON writes the snapshot as indented JSON.
+func (s *Snapshot) WriteJSON(
+	w io.Writer,
+) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// ReadSnapshot decodes a snapshot written by WriteJSON.
+func ReadSnapshot(
+	r io.Reader,
+) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+
+	return &s, nil
+}
+
+// hostFromAgent projects an agent onto its inventory fields.
+func hostFromAgent(
+	a osapi.Agent,
+) Host {
+	h := Host{
+		Hostname:         a.Hostname,
+		Fqdn:             a.Fqdn,
+		Status:           a.Status,
+		State:            a.State,
+		Labels:           a.Labels,
+		Architecture:     a.Architecture,
+		CPUCount:         a.CPUCount,
+		KernelVersion:    a.KernelVersion,
+		PackageMgr:       a.PackageMgr,
+		ServiceMgr:       a.ServiceMgr,
+		PrimaryInterface: a.PrimaryInterface,
+		Facts:            a.Facts,
+	}
+
+	if a.Memory != nil {
+		h.MemoryTotal = a.Memory.Total
+	}
+
+	if a.OSInfo != nil {
+		h.Distribution = a.OSInfo.Distribution
+		h.OSVersion = a.OSInfo.Version
+	}
+
+	for _, i := range a.Interfaces {
+		h.Interfaces = append(h.Interfaces, Interface(i))
+	}
+
+	for _, r := range a.Routes {
+		h.Routes = append(h.Routes, Route{
+			Destination: r.Destination,
+			Mask:        r.Mask,
+			Gateway:     r.Gateway,
+			Interface:   r.Interface,
+			Flags:       r.Flags,
+			Metric:      r.Metric,
+		})
+	}
+
+	return h
+}
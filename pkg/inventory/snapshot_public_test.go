@@ -0,0 +1,188 @@
+package inventory_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/inventory"
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type SnapshotPublicTestSuite struct {
+	suite.Suite
+}
+
+func TestSnapshotPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotPublicTestSuite))
+}
+
+func (s *SnapshotPublicTestSuite) TestCapture() {
+	tests := []struct {
+		name         string
+		code         int
+		body         string
+		validateFunc func(snap *inventory.Snapshot, err error)
+	}{
+		{
+			name: "projects agents sorted by hostname",
+			code: http.StatusOK,
+			body: `{"total":2,"agents":[
+				{"hostname":"web-02","status":"Ready","kernel_version":"6.1.0",
+				 "os_info":{"distribution":"Ubuntu","version":"24.04"},
+				 "memory":{"total":1024,"used":512,"free":512},
+				 "load_average":{"1min":1.5,"5min":1.0,"15min":0.5},
+				 "interfaces":[{"name":"eth0","ipv4":"10.0.0.2"}],
+				 "routes":[{"destination":"0.0.0.0","gateway":"10.0.0.1","interface":"eth0"}]},
+				{"hostname":"web-01","status":"Ready","labels":{"group":"web"}}]}`,
+			validateFunc: func(snap *inventory.Snapshot, err error) {
+				s.Require().NoError(err)
+				s.False(snap.TakenAt.IsZero())
+				s.Require().Len(snap.Hosts, 2)
+				s.Equal("web-01", snap.Hosts[0].Hostname)
+				s.Equal(map[string]string{"group": "web"}, snap.Hosts[0].Labels)
+
+				h, ok := snap.Host("web-02")
+				s.Require().True(ok)
+				s.Equal("Ubuntu", h.Distribution)
+				s.Equal("24.04", h.OSVersion)
+				s.Equal(1024, h.MemoryTotal)
+				s.Equal("10.0.0.2", h.Interfaces[0].IPv4)
+				s.Equal("10.0.0.1", h.Routes[0].Gateway)
+
+				_, ok = snap.Host("db-01")
+				s.False(ok)
+			},
+		},
+		{
+			name: "list error is wrapped",
+			code: http.StatusUnauthorized,
+			body: `{"error":"unauthorized"}`,
+			validateFunc: func(snap *inventory.Snapshot, err error) {
+				s.Nil(snap)
+				s.ErrorContains(err, "capture inventory")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			srv := httptest.NewServer(http.HandlerFunc(func(
+				w http.ResponseWriter,
+				_ *http.Request,
+			) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.code)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			snap, err := inventory.Capture(
+				context.Background(),
+				osapi.New(srv.URL, "test-token"),
+			)
+			tc.validateFunc(snap, err)
+		})
+	}
+}
+
+func (s *SnapshotPublicTestSuite) TestWriteJSON() {
+	taken := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	snap := inventory.FromAgents([]osapi.Agent{
+		{
+			Hostname:      "web-01",
+			KernelVersion: "6.1.0",
+			Labels:        map[string]string{"env": "prod"},
+			Interfaces:    []osapi.NetworkInterface{{Name: "eth0", IPv4: "10.0.0.1"}},
+			Facts:         map[string]any{"virtual": true},
+		},
+	}, taken)
+
+	tests := []struct {
+		name         string
+		writer       io.Writer
+		validateFunc func(out *bytes.Buffer, err error)
+	}{
+		{
+			name: "round trips through ReadSnapshot",
+			validateFunc: func(out *bytes.Buffer, err error) {
+				s.Require().NoError(err)
+				s.Contains(out.String(), `"kernel_version": "6.1.0"`)
+
+				got, err := inventory.ReadSnapshot(out)
+				s.Require().NoError(err)
+				s.Equal(snap, got)
+			},
+		},
+		{
+			name:   "write error is wrapped",
+			writer: errWriter{},
+			validateFunc: func(_ *bytes.Buffer, err error) {
+				s.ErrorContains(err, "write snapshot")
+				s.ErrorContains(err, "disk full")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			var buf bytes.Buffer
+
+			err := snap.WriteJSON(writerOr(tc.writer, &buf))
+			tc.validateFunc(&buf, err)
+		})
+	}
+}
+
+func (s *SnapshotPublicTestSuite) TestReadSnapshot() {
+	tests := []struct {
+		name         string
+		input        string
+		validateFunc func(snap *inventory.Snapshot, err error)
+	}{
+		{
+			name: "decodes hosts and capture time",
+			input: `{"taken_at":"2026-03-01T12:00:00Z","hosts":[
+				{"hostname":"web-01","kernel_version":"6.1.0","labels":{"env":"prod"}}]}`,
+			validateFunc: func(snap *inventory.Snapshot, err error) {
+				s.Require().NoError(err)
+				s.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), snap.TakenAt)
+
+				h, ok := snap.Host("web-01")
+				s.Require().True(ok)
+				s.Equal("6.1.0", h.KernelVersion)
+				s.Equal(map[string]string{"env": "prod"}, h.Labels)
+			},
+		},
+		{
+			name:  "malformed JSON is wrapped",
+			input: "{",
+			validateFunc: func(snap *inventory.Snapshot, err error) {
+				s.Nil(snap)
+				s.ErrorContains(err, "read snapshot")
+			},
+		},
+		{
+			name:  "empty input is wrapped",
+			input: "",
+			validateFunc: func(snap *inventory.Snapshot, err error) {
+				s.Nil(snap)
+				s.ErrorContains(err, "read snapshot")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			snap, err := inventory.ReadSnapshot(bytes.NewBufferString(tc.input))
+			tc.validateFunc(snap, err)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+// FileResource declares a local file that should be deployed to a
+// target.
+type FileResource struct {
+	// Local is the path of the file on disk. Required.
+	Local string
+
+	// Object is the Object Store name. Defaults to the base name of
+	// Local.
+	Object string
+
+	// ContentType is "raw" or "template". Defaults to "template" when
+	// Vars is set and "raw" otherwise.
+	ContentType string
+
+	// Path is the absolute destination path on the target hosts.
+	// Required.
+	Path string
+
+	// Mode is the file permission mode (e.g., "0644"). Optional.
+	Mode string
+
+	// Owner is the file owner user. Optional.
+	Owner string
+
+	// Group is the file owner group. Optional.
+	Group string
+
+	// Vars are the template variables for "template" content.
+	Vars map[string]any
+
+	// Target is the host target: a hostname, a label selector, or
+	// "_all". Required. "_any" is rejected because verify could not
+	// tell which host was deployed to.
+	Target string
+
+	// Requires lists tasks that must finish before the file is
+	// uploaded.
+	Requires []*Task
+}
+
+// File adds the tasks that converge a file resource and returns the
+// last one. It expands into three tasks:
+//
+//	<name>-upload   uploads Local to the Object Store if it changed,
+//	                replacing the stored object
+//	<name>-deploy   file.deploy.execute of the object to Target
+//	<name>-verify   checks every targeted host has the stored content
+//
+// The verify task reports Changed when the deploy wrote the file on any
+// host, so tasks that depend on it with OnlyIfChanged run only when the
+// file on a host actually changed.
+//
+// An invalid resource is recorded and reported by Validate, so Run
+// fails before any task starts. Template content is read from Local
+// and checked by ValidateTemplates unless WithTemplates registers the
+// object.
+func (p *Plan) File(
+	name string,
+	res FileResource,
+) *Task {
+	if res.Object == "" {
+		res.Object = filepath.Base(res.Local)
+	}
+
+	if res.ContentType == "" {
+		res.ContentType = "raw"
+		if len(res.Vars) > 0 {
+			res.ContentType = "template"
+		}
+	}
+
+	op := osapi.FileDeployOp{
+		ObjectName:  res.Object,
+		Path:        res.Path,
+		ContentType: res.ContentType,
+		Mode:        res.Mode,
+		Owner:       res.Owner,
+		Group:       res.Group,
+		Vars:        res.Vars,
+	}
+
+	if err := validateFileResource(res, op); err != nil {
+		p.errs = append(p.errs, fmt.Errorf("file resource %q: %w", name, err))
+	} else if res.ContentType == "template" {
+		if p.templateFiles == nil {
+			p.templateFiles = make(map[string]string)
+		}
+
+		p.templateFiles[res.Object] = res.Local
+	}
+
+	upload := p.TaskFunc(name+"-upload", func(
+		ctx context.Context,
+		client *osapi.Client,
+	) (*Result, error) {
+		changed, err := localChanged(ctx, client, res.Object, res.Local)
+		if err != nil {
+			return nil, err
+		}
+
+		if !changed.Changed {
+			return &Result{
+				Data: map[string]any{
+					"name":   changed.Name,
+					"sha256": changed.SHA256,
+				},
+			}, nil
+		}
+
+		// The API rejects replacing an object with different content
+		// unless the upload is forced.
+		resp, err := client.File.UploadFile(
+			ctx,
+			res.Object,
+			res.ContentType,
+			res.Local,
+			osapi.WithForce(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Result{
+			Changed: resp.Data.Changed,
+			Data: map[string]any{
+				"name":   resp.Data.Name,
+				"sha256": resp.Data.SHA256,
+			},
+		}, nil
+	})
+	upload.DependsOn(res.Requires...)
+
+	deploy := p.Task(name+"-deploy", &Op{
+		Operation: "file.deploy.execute",
+		Target:    res.Target,
+		Params:    op.Params(),
+	})
+	deploy.DependsOn(upload)
+
+	verify := p.TaskFuncWithResults(name+"-verify", func(
+		ctx context.Context,
+		client *osapi.Client,
+		results Results,
+	) (*Result, error) {
+		report, err := client.File.Drift(ctx, []osapi.DriftCheck{{
+			ObjectName: res.Object,
+			Path:       res.Path,
+			Target:     res.Target,
+		}})
+		if err != nil {
+			return nil, err
+		}
+
+		drift := report.Results[0]
+		if drift.Err != nil {
+			return nil, drift.Err
+		}
+
+		// The deploy changed if it wrote the file on any host.
+		changed := false
+		if r := results.Get(deploy.name); r != nil {
+			changed = r.Changed
+			for _, hr := range r.HostResults {
+				changed = changed || hr.Changed
+			}
+		}
+
+		result := &Result{
+			Changed: changed,
+			Data: map[string]any{
+				"object_name": res.Object,
+				"sha256":      drift.StoreSHA256,
+				"path":        res.Path,
+			},
+		}
+
+		for _, h := range drift.Hosts {
+			hr := HostResult{
+				Hostname: h.Hostname,
+				Data: map[string]any{
+					"state":  string(h.State),
+					"sha256": h.SHA256,
+				},
+			}
+
+			if h.Err != nil {
+				hr.Error = h.Err.Error()
+			}
+
+			result.HostResults = append(result.HostResults, hr)
+		}
+
+		if err := report.Err(); err != nil {
+			return result, err
+		}
+
+		if drift.Drifted() != nil || drift.Missing() != nil {
+			return result, fmt.Errorf(
+				"file %s not in sync: drifted %v, missing %v",
+				res.Path,
+				drift.Drifted(),
+				drift.Missing(),
+			)
+		}
+
+		return result, nil
+	})
+	verify.DependsOn(deploy)
+
+	return verify
+}
+
+// localChanged reports whether the file at local differs from the
+// object stored under name.
+func localChanged(
+	ctx context.Context,
+	client *osapi.Client,
+	name string,
+	local string,
+) (osapi.FileChanged, error) {
+	f, err := os.Open(local)
+	if err != nil {
+		return osapi.FileChanged{}, fmt.Errorf("open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	resp, err := client.File.Changed(ctx, name, f)
+	if err != nil {
+		return osapi.FileChanged{}, err
+	}
+
+	return resp.Data, nil
+}
+
+// validateFileResource checks the fields File needs beyond what the
+// deploy operation validates.
+func validateFileResource(
+	res FileResource,
+	op osapi.FileDeployOp,
+) error {
+	switch {
+	case res.Local == "":
+		return errors.New("local path is required")
+	case res.Target == "":
+		return errors.New("target is required")
+	case res.Target == "_any":
+		return errors.New(`target "_any" is not supported; use a hostname, label selector, or "_all"`)
+	case res.Path != "" && !path.IsAbs(res.Path):
+		return fmt.Errorf("path %q must be absolute", res.Path)
+	}
+
+	return op.Validate()
+}
@@ -0,0 +1,492 @@
+package orchestrator_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/orchestrator"
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type FilePublicTestSuite struct {
+	suite.Suite
+
+	local string
+}
+
+func TestFilePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(FilePublicTestSuite))
+}
+
+func (s *FilePublicTestSuite) SetupTest() {
+	s.local = filepath.Join(s.T().TempDir(), "nginx.conf")
+	s.Require().NoError(os.WriteFile(s.local, []byte("worker_processes 4;"), 0o600))
+}
+
+// fleet fakes the Object Store, job, agent, and file status endpoints
+// for two web hosts. Deploying copies the stored object's hash to each
+// host unless the host is broken.
+type fleet struct {
+	mu      sync.Mutex
+	objects map[string]string
+	hosts   map[string]string
+	broken  map[string]bool
+	result  map[string]any
+	deploys []map[string]any
+}
+
+func newFleet() *fleet {
+	return &fleet{
+		objects: make(map[string]string),
+		hosts:   make(map[string]string),
+		broken:  make(map[string]bool),
+	}
+}
+
+func (f *fleet) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	jobID := "00000000-0000-0000-0000-000000000001"
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/agent":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"agents": []map[string]any{
+				{"hostname": "web-01", "status": "Ready", "labels": map[string]string{"role": "web"}},
+				{"hostname": "web-02", "status": "Ready", "labels": map[string]string{"role": "web"}},
+			},
+			"total": 2,
+		})
+	case r.Method == http.MethodPost && r.URL.Path == "/file":
+		file, _, _ := r.FormFile("file")
+		content, _ := io.ReadAll(file)
+		name := r.FormValue("name")
+		sum := fmt.Sprintf("%x", sha256.Sum256(content))
+
+		// Like the API, refuse to replace different content without force.
+		if stored, ok := f.objects[name]; ok && stored != sum && r.URL.Query().Get("force") != "true" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"file already exists with different content"}`))
+			return
+		}
+
+		f.objects[name] = sum
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"name":         name,
+			"sha256":       f.objects[name],
+			"size":         len(content),
+			"changed":      true,
+			"content_type": r.FormValue("content_type"),
+		})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/file/"):
+		name := strings.TrimPrefix(r.URL.Path, "/file/")
+		sum, ok := f.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"file not found"}`))
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"name":         name,
+			"sha256":       sum,
+			"size":         1,
+			"content_type": "raw",
+		})
+	case r.Method == http.MethodPost && r.URL.Path == "/job":
+		var req struct {
+			Operation map[string]any `json:"operation"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		data, _ := req.Operation["data"].(map[string]any)
+		f.deploys = append(f.deploys, data)
+
+		sum := f.objects[data["object_name"].(string)]
+
+		var results []map[string]any
+		for _, host := range []string{"web-01", "web-02"} {
+			changed := false
+			if !f.broken[host] && f.hosts[host] != sum {
+				f.hosts[host] = sum
+				changed = true
+			}
+
+			results = append(results, map[string]any{"hostname": host, "changed": changed})
+		}
+		f.result = map[string]any{"results": results}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"job_id": jobID, "status": "pending"})
+	case r.Method == http.MethodGet && r.URL.Path == "/job/"+jobID:
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":     jobID,
+			"status": "completed",
+			"result": f.result,
+		})
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/file/status"):
+		host := strings.Split(r.URL.Path, "/")[2]
+
+		status := "in-sync"
+		switch sum := f.hosts[host]; {
+		case sum == "":
+			status = "missing"
+		case sum != f.objects["nginx.conf"]:
+			status = "drifted"
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"job_id":   jobID,
+			"hostname": host,
+			"path":     "/etc/nginx/nginx.conf",
+			"status":   status,
+			"sha256":   f.hosts[host],
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *FilePublicTestSuite) TestFile() {
+	stored := fmt.Sprintf("%x", sha256.Sum256([]byte("worker_processes 4;")))
+
+	tests := []struct {
+		name         string
+		local        func() string
+		setup        func(*fleet)
+		validateFunc func(report *orchestrator.Report, err error, f *fleet)
+	}{
+		{
+			name: "when file is new uploads, deploys, and runs handler",
+			validateFunc: func(report *orchestrator.Report, err error, f *fleet) {
+				s.Require().NoError(err)
+				s.Equal(map[string]orchestrator.Status{
+					"nginx-upload":  orchestrator.StatusChanged,
+					"nginx-deploy":  orchestrator.StatusUnchanged,
+					"nginx-verify":  orchestrator.StatusChanged,
+					"restart-nginx": orchestrator.StatusChanged,
+				}, statusMap(report))
+				s.Equal(stored, f.hosts["web-01"])
+
+				verify := report.Tasks[2]
+				s.Len(verify.HostResults, 2)
+				s.Equal("in-sync", verify.HostResults[0].Data["state"])
+			},
+		},
+		{
+			name: "when every host is in sync skips handler",
+			setup: func(f *fleet) {
+				f.objects["nginx.conf"] = stored
+				f.hosts["web-01"] = stored
+				f.hosts["web-02"] = stored
+			},
+			validateFunc: func(report *orchestrator.Report, err error, _ *fleet) {
+				s.Require().NoError(err)
+				s.Equal(map[string]orchestrator.Status{
+					"nginx-upload":  orchestrator.StatusUnchanged,
+					"nginx-deploy":  orchestrator.StatusUnchanged,
+					"nginx-verify":  orchestrator.StatusUnchanged,
+					"restart-nginx": orchestrator.StatusSkipped,
+				}, statusMap(report))
+			},
+		},
+		{
+			name: "when local file was edited replaces stored object and runs handler",
+			setup: func(f *fleet) {
+				f.objects["nginx.conf"] = "old"
+				f.hosts["web-01"] = "old"
+				f.hosts["web-02"] = "old"
+			},
+			validateFunc: func(report *orchestrator.Report, err error, f *fleet) {
+				s.Require().NoError(err)
+				s.Equal(orchestrator.StatusChanged, statusMap(report)["nginx-upload"])
+				s.Equal(orchestrator.StatusChanged, statusMap(report)["restart-nginx"])
+				s.Equal(stored, f.objects["nginx.conf"])
+				s.Equal(stored, f.hosts["web-01"])
+				s.Equal(stored, f.hosts["web-02"])
+			},
+		},
+		{
+			name: "when a host drifted redeploys and runs handler",
+			setup: func(f *fleet) {
+				f.objects["nginx.conf"] = stored
+				f.hosts["web-01"] = stored
+				f.hosts["web-02"] = "edited"
+			},
+			validateFunc: func(report *orchestrator.Report, err error, f *fleet) {
+				s.Require().NoError(err)
+				s.Equal(orchestrator.StatusUnchanged, statusMap(report)["nginx-upload"])
+				s.Equal(orchestrator.StatusChanged, statusMap(report)["nginx-verify"])
+				s.Equal(orchestrator.StatusChanged, statusMap(report)["restart-nginx"])
+				s.Equal(stored, f.hosts["web-02"])
+			},
+		},
+		{
+			name: "when a host stays out of sync verify fails",
+			setup: func(f *fleet) {
+				f.broken["web-02"] = true
+			},
+			validateFunc: func(report *orchestrator.Report, err error, _ *fleet) {
+				s.Require().Error(err)
+				s.Equal(orchestrator.StatusFailed, statusMap(report)["nginx-verify"])
+				s.ErrorContains(report.Tasks[2].Error, "missing [web-02]")
+				s.NotEqual(orchestrator.StatusChanged, statusMap(report)["restart-nginx"])
+			},
+		},
+		{
+			name:  "when local file is missing upload fails",
+			local: func() string { return filepath.Join(s.T().TempDir(), "nginx.conf") },
+			validateFunc: func(report *orchestrator.Report, err error, f *fleet) {
+				s.Require().Error(err)
+				s.Equal(orchestrator.StatusFailed, statusMap(report)["nginx-upload"])
+				s.Empty(f.deploys)
+			},
+		},
+		{
+			name:  "when local path is empty fails validation",
+			local: func() string { return "" },
+			validateFunc: func(report *orchestrator.Report, err error, f *fleet) {
+				s.Nil(report)
+				s.ErrorContains(err, `plan validation: file resource "nginx": local path is required`)
+				s.Empty(f.objects)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			defer withShortPoll()()
+
+			f := newFleet()
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+
+			server := httptest.NewServer(f)
+			defer server.Close()
+
+			local := s.local
+			if tc.local != nil {
+				local = tc.local()
+			}
+
+			plan := orchestrator.NewPlan(osapi.New(server.URL, "test-token"))
+
+			conf := plan.File("nginx", orchestrator.FileResource{
+				Local:  local,
+				Object: "nginx.conf",
+				Path:   "/etc/nginx/nginx.conf",
+				Mode:   "0644",
+				Target: "role:web",
+			})
+
+			restart := plan.TaskFunc("restart-nginx", taskFunc(true, nil))
+			restart.DependsOn(conf)
+			restart.OnlyIfChanged()
+
+			report, err := plan.Run(context.Background())
+			tc.validateFunc(report, err, f)
+		})
+	}
+}
+
+func (s *FilePublicTestSuite) TestFileValidate() {
+	tests := []struct {
+		name         string
+		res          orchestrator.FileResource
+		validateFunc func(err error)
+	}{
+		{
+			name: "when resource is valid returns nil",
+			res: orchestrator.FileResource{
+				Local:  "files/nginx.conf",
+				Path:   "/etc/nginx/nginx.conf",
+				Target: "role:web",
+			},
+			validateFunc: func(err error) {
+				s.NoError(err)
+			},
+		},
+		{
+			name: "when target is empty returns error",
+			res: orchestrator.FileResource{
+				Local: "files/nginx.conf",
+				Path:  "/etc/nginx/nginx.conf",
+			},
+			validateFunc: func(err error) {
+				s.EqualError(err, `file resource "nginx": target is required`)
+			},
+		},
+		{
+			name: "when target is _any returns error",
+			res: orchestrator.FileResource{
+				Local:  "files/nginx.conf",
+				Path:   "/etc/nginx/nginx.conf",
+				Target: "_any",
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `target "_any" is not supported`)
+			},
+		},
+		{
+			name: "when path is empty returns error",
+			res: orchestrator.FileResource{
+				Local:  "files/nginx.conf",
+				Target: "_all",
+			},
+			validateFunc: func(err error) {
+				s.EqualError(err, `file resource "nginx": path is required`)
+			},
+		},
+		{
+			name: "when path is relative returns error",
+			res: orchestrator.FileResource{
+				Local:  "files/nginx.conf",
+				Path:   "etc/nginx/nginx.conf",
+				Target: "_all",
+			},
+			validateFunc: func(err error) {
+				s.EqualError(err, `file resource "nginx": path "etc/nginx/nginx.conf" must be absolute`)
+			},
+		},
+		{
+			name: "when mode is invalid returns error",
+			res: orchestrator.FileResource{
+				Local:  "files/nginx.conf",
+				Path:   "/etc/nginx/nginx.conf",
+				Mode:   "rw-r--r--",
+				Target: "_all",
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `invalid file mode "rw-r--r--"`)
+			},
+		},
+		{
+			name: "when vars are set on raw content returns error",
+			res: orchestrator.FileResource{
+				Local:       "files/nginx.conf",
+				ContentType: "raw",
+				Path:        "/etc/nginx/nginx.conf",
+				Vars:        map[string]any{"port": 80},
+				Target:      "_all",
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `vars require content type "template"`)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			plan := orchestrator.NewPlan(nil)
+			plan.File("nginx", tc.res)
+
+			tc.validateFunc(plan.Validate())
+		})
+	}
+}
+
+func (s *FilePublicTestSuite) TestFileTemplate() {
+	tests := []struct {
+		name         string
+		content      string
+		validateFunc func(report *orchestrator.Report, err error, f *fleet)
+	}{
+		{
+			name:    "when template renders on every host runs",
+			content: "listen {{ .Vars.port }};",
+			validateFunc: func(_ *orchestrator.Report, err error, f *fleet) {
+				s.NoError(err)
+				s.Contains(f.objects, "nginx.conf")
+			},
+		},
+		{
+			name:    "when template misses a var fails before upload",
+			content: "listen {{ .Vars.listen }};",
+			validateFunc: func(report *orchestrator.Report, err error, f *fleet) {
+				s.Nil(report)
+				s.ErrorContains(err, "template validation")
+				s.ErrorContains(err, "on web-01")
+				s.Empty(f.objects)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			defer withShortPoll()()
+
+			s.Require().NoError(os.WriteFile(s.local, []byte(tc.content), 0o600))
+
+			f := newFleet()
+			server := httptest.NewServer(f)
+			defer server.Close()
+
+			plan := orchestrator.NewPlan(osapi.New(server.URL, "test-token"))
+			plan.File("nginx", orchestrator.FileResource{
+				Local:  s.local,
+				Path:   "/etc/nginx/nginx.conf",
+				Vars:   map[string]any{"port": 80},
+				Target: "role:web",
+			})
+
+			report, err := plan.Run(context.Background())
+			tc.validateFunc(report, err, f)
+		})
+	}
+}
+
+func (s *FilePublicTestSuite) TestFileTasks() {
+	plan := orchestrator.NewPlan(nil)
+	setup := plan.TaskFunc("install-nginx", taskFunc(false, nil))
+
+	verify := plan.File("app", orchestrator.FileResource{
+		Local:    "/srv/conf/app.conf.tmpl",
+		Path:     "/etc/app.conf",
+		Vars:     map[string]any{"port": 8080},
+		Target:   "_all",
+		Requires: []*orchestrator.Task{setup},
+	})
+
+	s.Equal("app-verify", verify.Name())
+
+	tasks := plan.Tasks()
+	s.Require().Len(tasks, 4)
+
+	upload, deploy := tasks[1], tasks[2]
+	s.Equal("app-upload", upload.Name())
+	s.Equal([]*orchestrator.Task{setup}, upload.Dependencies())
+	s.Equal([]*orchestrator.Task{upload}, deploy.Dependencies())
+	s.Equal([]*orchestrator.Task{deploy}, verify.Dependencies())
+
+	s.Equal(&orchestrator.Op{
+		Operation: "file.deploy.execute",
+		Target:    "_all",
+		Params: map[string]any{
+			"object_name":  "app.conf.tmpl",
+			"path":         "/etc/app.conf",
+			"content_type": "template",
+			"vars":         map[string]any{"port": 8080},
+		},
+	}, deploy.Operation())
+}
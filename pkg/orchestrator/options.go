@@ -55,6 +55,9 @@ type Hooks struct {
 type PlanConfig struct {
 	OnErrorStrategy ErrorStrategy
 	Hooks           *Hooks
+	// Templates maps Object Store names to template content for
+	// ValidateTemplates.
+	Templates map[string]string
 }
 
 // PlanOption is a functional option for NewPlan.
@@ -77,3 +80,14 @@ func WithHooks(
 		cfg.Hooks = &hooks
 	}
 }
+
+// WithTemplates registers template content by Object Store name. Run
+// then calls ValidateTemplates before executing any task, so template
+// errors surface before the rollout starts.
+func WithTemplates(
+	templates map[string]string,
+) PlanOption {
+	return func(cfg *PlanConfig) {
+		cfg.Templates = templates
+	}
+}
@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,9 +11,16 @@ import (
 
 // Plan is a DAG of tasks with dependency edges.
 type Plan struct {
-	client *osapi.Client
-	tasks  []*Task
-	config PlanConfig
+	client   *osapi.Client
+	tasks    []*Task
+	handlers []*Task
+	config   PlanConfig
+	// errs holds errors from building the plan, such as invalid file
+	// resources, reported by Validate.
+	errs []error
+	// templateFiles maps Object Store names to the local template
+	// files File resources upload, for ValidateTemplates.
+	templateFiles map[string]string
 }
 
 // NewPlan creates a new plan bound to an OSAPI client.
@@ -80,6 +88,48 @@ func (p *Plan) TaskFuncWithResults(
 	return t
 }
 
+// Handler creates a declarative handler, adds it to the plan, and
+// returns it. Handlers are not part of the DAG: they run only when a
+// task that notifies them with Notify reports Changed.
+func (p *Plan) Handler(
+	name string,
+	op *Op,
+) *Task {
+	t := NewTask(name, op)
+	p.handlers = append(p.handlers, t)
+
+	return t
+}
+
+// HandlerFunc creates a functional handler, adds it to the plan, and
+// returns it.
+func (p *Plan) HandlerFunc(
+	name string,
+	fn TaskFn,
+) *Task {
+	t := NewTaskFunc(name, fn)
+	p.handlers = append(p.handlers, t)
+
+	return t
+}
+
+// FlushHandlers adds a task that runs the handlers notified so far,
+// rather than waiting for the end of the plan, and returns it. Order it
+// with DependsOn like any other task.
+func (p *Plan) FlushHandlers(
+	name string,
+) *Task {
+	t := &Task{name: name, flush: true}
+	p.tasks = append(p.tasks, t)
+
+	return t
+}
+
+// Handlers returns all handlers in the plan.
+func (p *Plan) Handlers() []*Task {
+	return p.handlers
+}
+
 // Tasks returns all tasks in the plan.
 func (p *Plan) Tasks() []*Task {
 	return p.tasks
@@ -106,7 +156,10 @@ func (p *Plan) Explain() string {
 
 		for _, t := range level {
 			kind := "op"
-			if t.IsFunc() {
+			switch {
+			case t.flush:
+				kind = "flush"
+			case t.IsFunc():
 				kind = "fn"
 			}
 
@@ -130,6 +183,10 @@ func (p *Plan) Explain() string {
 				flags = append(flags, "when")
 			}
 
+			if len(t.notify) > 0 {
+				flags = append(flags, "notify "+strings.Join(t.notify, ", "))
+			}
+
 			if len(flags) > 0 {
 				fmt.Fprintf(&b, " (%s)", strings.Join(flags, ", "))
 			}
@@ -138,6 +195,19 @@ func (p *Plan) Explain() string {
 		}
 	}
 
+	if len(p.handlers) > 0 {
+		fmt.Fprintf(&b, "\nHandlers:\n")
+
+		for _, h := range p.handlers {
+			kind := "op"
+			if h.IsFunc() {
+				kind = "fn"
+			}
+
+			fmt.Fprintf(&b, "  %s [%s]\n", h.name, kind)
+		}
+	}
+
 	return b.String()
 }
 
@@ -152,9 +222,16 @@ func (p *Plan) Levels() ([][]*Task, error) {
 	return levelize(p.tasks), nil
 }
 
-// Validate checks the plan for errors: duplicate names and cycles.
+// Validate checks the plan for errors: invalid file resources,
+// duplicate names, cycles, and notifications of unknown handlers.
+// Handlers may not have dependencies, notify other handlers, or use
+// OnlyIfChanged, since they run only when a notifier changed.
 func (p *Plan) Validate() error {
-	names := make(map[string]bool, len(p.tasks))
+	if err := errors.Join(p.errs...); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(p.tasks)+len(p.handlers))
 
 	for _, t := range p.tasks {
 		if names[t.name] {
@@ -164,10 +241,50 @@ func (p *Plan) Validate() error {
 		names[t.name] = true
 	}
 
+	handlers := make(map[string]bool, len(p.handlers))
+
+	for _, h := range p.handlers {
+		if names[h.name] {
+			return fmt.Errorf("duplicate task name: %q", h.name)
+		}
+
+		names[h.name] = true
+
+		if len(h.deps) > 0 {
+			return fmt.Errorf("handler %q cannot have dependencies", h.name)
+		}
+
+		if len(h.notify) > 0 {
+			return fmt.Errorf("handler %q cannot notify handlers", h.name)
+		}
+
+		if h.requiresChange {
+			return fmt.Errorf("handler %q cannot use OnlyIfChanged", h.name)
+		}
+
+		handlers[h.name] = true
+	}
+
+	for _, t := range p.tasks {
+		for _, name := range t.notify {
+			if !handlers[name] {
+				return fmt.Errorf("task %q notifies unknown handler %q", t.name, name)
+			}
+		}
+
+		for _, dep := range t.deps {
+			if handlers[dep.name] {
+				return fmt.Errorf("task %q depends on handler %q", t.name, dep.name)
+			}
+		}
+	}
+
 	return p.detectCycle()
 }
 
 // Run validates the plan, resolves the DAG, and executes tasks.
+// Templates registered with WithTemplates or deployed by File are
+// validated first.
 func (p *Plan) Run(
 	ctx context.Context,
 ) (*Report, error) {
@@ -175,6 +292,12 @@ func (p *Plan) Run(
 		return nil, fmt.Errorf("plan validation: %w", err)
 	}
 
+	if len(p.config.Templates) > 0 || len(p.templateFiles) > 0 {
+		if err := p.ValidateTemplates(ctx); err != nil {
+			return nil, fmt.Errorf("template validation: %w", err)
+		}
+	}
+
 	runner := newRunner(p)
 
 	return runner.run(ctx)
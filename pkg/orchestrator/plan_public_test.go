@@ -3,9 +3,11 @@ package orchestrator_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -232,6 +234,53 @@ func (s *PlanPublicTestSuite) TestRun() {
 		s.Error(err)
 		s.Contains(err.Error(), "cycle")
 	})
+
+	s.Run("template validation failure creates no jobs", func() {
+		var jobs atomic.Int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(
+			w http.ResponseWriter,
+			r *http.Request,
+		) {
+			if r.URL.Path != "/agent" {
+				jobs.Add(1)
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"agents": []map[string]any{
+					{"hostname": "db-01", "status": "Ready"},
+				},
+				"total": 1,
+			})
+		}))
+		defer srv.Close()
+
+		plan := orchestrator.NewPlan(
+			osapi.New(srv.URL, "test-token"),
+			orchestrator.WithTemplates(map[string]string{
+				"app.conf.tmpl": "{{ .Facts.cpu_count }}",
+			}),
+		)
+		plan.Task("deploy", &orchestrator.Op{
+			Operation: "file.deploy.execute",
+			Target:    "_all",
+			Params: map[string]any{
+				"object_name":  "app.conf.tmpl",
+				"path":         "/etc/app.conf",
+				"content_type": "template",
+			},
+		})
+
+		report, err := plan.Run(context.Background())
+		s.Nil(report)
+		s.Require().Error(err)
+		s.Contains(err.Error(), "template validation")
+		s.Zero(jobs.Load())
+	})
 }
 
 func (s *PlanPublicTestSuite) TestRunOnlyIfChanged() {
@@ -521,6 +570,8 @@ func (s *PlanPublicTestSuite) TestRunOpTask() {
 		pollResponses []pollResponse
 		op            *orchestrator.Op
 		noServer      bool
+		pollInterval  time.Duration
+		timeout       time.Duration
 		validateFunc  func(report *orchestrator.Report, err error)
 	}{
 		{
@@ -630,6 +681,23 @@ func (s *PlanPublicTestSuite) TestRunOpTask() {
 				s.Equal(orchestrator.StatusChanged, report.Tasks[0].Status)
 			},
 		},
+		{
+			name:       "first poll does not wait for the poll interval",
+			createCode: http.StatusCreated,
+			pollResponses: []pollResponse{
+				{status: "completed", result: map[string]any{"changed": true}},
+			},
+			op: &orchestrator.Op{
+				Operation: "node.hostname.get",
+				Target:    "_any",
+			},
+			pollInterval: 5 * time.Second,
+			timeout:      time.Second,
+			validateFunc: func(report *orchestrator.Report, err error) {
+				s.Require().NoError(err)
+				s.Equal(orchestrator.StatusChanged, report.Tasks[0].Status)
+			},
+		},
 		{
 			name:     "requires client",
 			noServer: true,
@@ -658,7 +726,14 @@ func (s *PlanPublicTestSuite) TestRunOpTask() {
 				return
 			}
 
-			restore := withShortPoll()
+			var restore func()
+			if tt.pollInterval > 0 {
+				orig := orchestrator.DefaultPollInterval
+				orchestrator.DefaultPollInterval = tt.pollInterval
+				restore = func() { orchestrator.DefaultPollInterval = orig }
+			} else {
+				restore = withShortPoll()
+			}
 			defer restore()
 
 			srv := opServer(s, tt.createCode, tt.createErrMsg, tt.pollResponses)
@@ -669,7 +744,14 @@ func (s *PlanPublicTestSuite) TestRunOpTask() {
 			plan := orchestrator.NewPlan(client)
 			plan.Task("op-task", tt.op)
 
-			report, runErr := plan.Run(context.Background())
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			report, runErr := plan.Run(ctx)
 			tt.validateFunc(report, runErr)
 		})
 	}
@@ -730,6 +812,136 @@ func (s *PlanPublicTestSuite) TestRunOpTaskParams() {
 	s.Equal("uptime", data["command"])
 }
 
+func (s *PlanPublicTestSuite) TestRunAgentOps() {
+	tests := []struct {
+		name         string
+		op           *orchestrator.Op
+		state        string
+		validateFunc func(report *orchestrator.Report, err error, calls []string)
+	}{
+		{
+			name:  "drain ready agent reports changed",
+			op:    &orchestrator.Op{Operation: "agent.drain", Target: "web-01"},
+			state: "Ready",
+			validateFunc: func(report *orchestrator.Report, err error, calls []string) {
+				s.Require().NoError(err)
+				s.Equal(orchestrator.StatusChanged, report.Tasks[0].Status)
+				s.Equal("Cordoned", report.Tasks[0].Data["state"])
+				s.Equal(0, report.Tasks[0].Data["jobs_awaited"])
+				s.Contains(calls, "POST /agent/web-01/drain")
+			},
+		},
+		{
+			name:  "drain cordoned agent reports unchanged",
+			op:    &orchestrator.Op{Operation: "agent.drain", Target: "web-01"},
+			state: "Cordoned",
+			validateFunc: func(report *orchestrator.Report, err error, calls []string) {
+				s.Require().NoError(err)
+				s.Equal(orchestrator.StatusUnchanged, report.Tasks[0].Status)
+				s.NotContains(calls, "POST /agent/web-01/drain")
+			},
+		},
+		{
+			name:  "undrain cordoned agent reports changed",
+			op:    &orchestrator.Op{Operation: "agent.undrain", Target: "web-01"},
+			state: "Cordoned",
+			validateFunc: func(report *orchestrator.Report, err error, calls []string) {
+				s.Require().NoError(err)
+				s.Equal(orchestrator.StatusChanged, report.Tasks[0].Status)
+				s.Contains(calls, "POST /agent/web-01/undrain")
+			},
+		},
+		{
+			name:  "undrain ready agent reports unchanged",
+			op:    &orchestrator.Op{Operation: "agent.undrain", Target: "web-01"},
+			state: "Ready",
+			validateFunc: func(report *orchestrator.Report, err error, calls []string) {
+				s.Require().NoError(err)
+				s.Equal(orchestrator.StatusUnchanged, report.Tasks[0].Status)
+				s.NotContains(calls, "POST /agent/web-01/undrain")
+			},
+		},
+		{
+			name:  "undrain unknown agent fails",
+			op:    &orchestrator.Op{Operation: "agent.undrain", Target: "db-01"},
+			state: "Ready",
+			validateFunc: func(report *orchestrator.Report, err error, _ []string) {
+				s.Error(err)
+				s.Equal(orchestrator.StatusFailed, report.Tasks[0].Status)
+				s.Contains(err.Error(), "undrain agent")
+			},
+		},
+		{
+			name:  "drain broadcast target fails",
+			op:    &orchestrator.Op{Operation: "agent.drain", Target: "group:web"},
+			state: "Ready",
+			validateFunc: func(report *orchestrator.Report, err error, calls []string) {
+				s.Error(err)
+				s.Contains(err.Error(), "requires a single hostname target")
+				s.Empty(calls)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			var (
+				mu    sync.Mutex
+				calls []string
+				state = tc.state
+			)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(
+				w http.ResponseWriter,
+				r *http.Request,
+			) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.URL.Path {
+				case "/agent/web-01":
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"hostname": "web-01",
+						"status":   "Ready",
+						"state":    state,
+					})
+				case "/agent/web-01/drain":
+					state = "Cordoned"
+					_ = json.NewEncoder(w).Encode(map[string]any{"message": "ok"})
+				case "/agent/web-01/undrain":
+					state = "Ready"
+					_ = json.NewEncoder(w).Encode(map[string]any{"message": "ok"})
+				case "/job":
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"items":       []any{},
+						"total_items": 0,
+					})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"error":"not found"}`))
+				}
+			}))
+			defer srv.Close()
+
+			restore := withShortPoll()
+			defer restore()
+
+			plan := orchestrator.NewPlan(osapi.New(srv.URL, "test-token"))
+			plan.Task("agent", tc.op)
+
+			report, err := plan.Run(context.Background())
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			tc.validateFunc(report, err, calls)
+		})
+	}
+}
+
 func (s *PlanPublicTestSuite) TestRunOpTaskErrors() {
 	tests := []struct {
 		name          string
@@ -803,6 +1015,23 @@ func (s *PlanPublicTestSuite) TestRunOpTaskErrors() {
 				s.Contains(err.Error(), "job failed")
 			},
 		},
+		{
+			name:       "job partial failure fails the task",
+			createCode: http.StatusCreated,
+			pollResponses: []pollResponse{
+				{status: "processing"},
+				{status: "partial_failure"},
+			},
+			useServer: true,
+			validateFunc: func(report *orchestrator.Report, err error) {
+				s.Error(err)
+				s.Contains(err.Error(), "partial_failure")
+				s.Equal(orchestrator.StatusFailed, report.Tasks[0].Status)
+
+				var target *osapi.JobFailedError
+				s.True(errors.As(err, &target))
+			},
+		},
 		{
 			name:       "context canceled during poll",
 			createCode: http.StatusCreated,
@@ -843,7 +1072,7 @@ func (s *PlanPublicTestSuite) TestRunOpTaskErrors() {
 			},
 		},
 		{
-			name:       "context canceled before first poll tick",
+			name:       "context canceled while waiting for next poll",
 			createCode: http.StatusCreated,
 			pollResponses: []pollResponse{
 				{status: "pending"},
@@ -1115,6 +1344,253 @@ func (s *PlanPublicTestSuite) TestRunHooks() {
 	})
 }
 
+func (s *PlanPublicTestSuite) TestRunHandlers() {
+	tests := []struct {
+		name         string
+		setup        func(plan *orchestrator.Plan, rec *recorder)
+		validateFunc func(report *orchestrator.Report, err error, ran []string)
+	}{
+		{
+			name: "runs once after all tasks when any notifier changed",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				a := plan.TaskFunc("conf-a", taskFunc(true, rec.record("conf-a"))).Notify("restart")
+				b := plan.TaskFunc("conf-b", taskFunc(false, rec.record("conf-b"))).Notify("restart")
+				c := plan.TaskFunc("conf-c", taskFunc(true, rec.record("conf-c"))).Notify("restart")
+				c.DependsOn(a, b)
+				plan.TaskFunc("last", taskFunc(false, rec.record("last"))).DependsOn(c)
+
+				plan.HandlerFunc("restart", taskFunc(true, rec.record("restart")))
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.Require().NoError(err)
+				s.Equal("restart", ran[len(ran)-1])
+				s.Len(ran, 5)
+
+				handler := report.Tasks[len(report.Tasks)-1]
+				s.Equal("restart", handler.Name)
+				s.Equal(orchestrator.StatusChanged, handler.Status)
+				s.Equal([]string{"conf-a", "conf-c"}, handler.Notifiers)
+				s.Empty(report.Tasks[0].Notifiers)
+			},
+		},
+		{
+			name: "does not run when no notifier changed",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				plan.TaskFunc("conf", taskFunc(false, nil)).Notify("restart")
+				plan.HandlerFunc("restart", taskFunc(true, rec.record("restart")))
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.Require().NoError(err)
+				s.Empty(ran)
+				s.Len(report.Tasks, 1)
+			},
+		},
+		{
+			name: "runs handlers in the order they were added",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				plan.TaskFunc("conf", taskFunc(true, nil)).Notify("second", "first")
+				plan.HandlerFunc("first", taskFunc(true, rec.record("first")))
+				plan.HandlerFunc("second", taskFunc(true, rec.record("second")))
+			},
+			validateFunc: func(_ *orchestrator.Report, err error, ran []string) {
+				s.Require().NoError(err)
+				s.Equal([]string{"first", "second"}, ran)
+			},
+		},
+		{
+			name: "flush runs notified handlers and later notifications run again",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				conf := plan.TaskFunc("conf", taskFunc(true, rec.record("conf"))).Notify("restart")
+				flush := plan.FlushHandlers("flush").DependsOn(conf)
+				check := plan.TaskFunc("check", taskFunc(false, rec.record("check"))).DependsOn(flush)
+				plan.TaskFunc("conf-2", taskFunc(true, rec.record("conf-2"))).
+					Notify("restart").
+					DependsOn(check)
+
+				plan.HandlerFunc("restart", taskFunc(true, rec.record("restart")))
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.Require().NoError(err)
+				s.Equal([]string{"conf", "restart", "check", "conf-2", "restart"}, ran)
+
+				names := make([]string, len(report.Tasks))
+				for i, t := range report.Tasks {
+					names[i] = t.Name
+				}
+				s.Equal([]string{"conf", "flush", "restart", "check", "conf-2", "restart"}, names)
+				s.Equal(orchestrator.StatusChanged, report.Tasks[1].Status)
+				s.Equal([]any{"restart"}, toAny(report.Tasks[1].Data["handlers"]))
+				s.Equal([]string{"conf"}, report.Tasks[2].Notifiers)
+				s.Equal([]string{"conf-2"}, report.Tasks[5].Notifiers)
+			},
+		},
+		{
+			name: "flush with nothing notified is unchanged",
+			setup: func(plan *orchestrator.Plan, _ *recorder) {
+				plan.FlushHandlers("flush")
+				plan.HandlerFunc("restart", taskFunc(true, nil))
+			},
+			validateFunc: func(report *orchestrator.Report, err error, _ []string) {
+				s.Require().NoError(err)
+				s.Len(report.Tasks, 1)
+				s.Equal(orchestrator.StatusUnchanged, report.Tasks[0].Status)
+			},
+		},
+		{
+			name: "failed handler fails the plan",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				plan.TaskFunc("conf", taskFunc(true, nil)).Notify("restart", "reload")
+				plan.HandlerFunc("restart", failFunc("restart failed"))
+				plan.HandlerFunc("reload", taskFunc(true, rec.record("reload")))
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.ErrorContains(err, "restart failed")
+				s.Equal(orchestrator.StatusFailed, statusMap(report)["restart"])
+				s.Empty(ran)
+			},
+		},
+		{
+			name: "failed handler with continue runs the rest",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				plan.TaskFunc("conf", taskFunc(true, nil)).Notify("restart", "reload")
+				plan.HandlerFunc("restart", failFunc("restart failed")).OnError(orchestrator.Continue)
+				plan.HandlerFunc("reload", taskFunc(true, rec.record("reload")))
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.NoError(err)
+				s.Equal(orchestrator.StatusFailed, statusMap(report)["restart"])
+				s.Equal([]string{"reload"}, ran)
+			},
+		},
+		{
+			name: "failed flush handler fails the flush task",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				conf := plan.TaskFunc("conf", taskFunc(true, nil)).Notify("restart")
+				flush := plan.FlushHandlers("flush").DependsOn(conf)
+				plan.TaskFunc("after", taskFunc(true, rec.record("after"))).
+					DependsOn(flush)
+				plan.HandlerFunc("restart", failFunc("restart failed"))
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.ErrorContains(err, "restart failed")
+				s.Equal(orchestrator.StatusFailed, statusMap(report)["flush"])
+				s.Equal(orchestrator.StatusFailed, statusMap(report)["restart"])
+				s.Empty(ran)
+			},
+		},
+		{
+			name: "retried flush reruns only the failed handler",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				conf := plan.TaskFunc("conf", taskFunc(true, nil)).Notify("reload", "restart")
+				plan.FlushHandlers("flush").
+					DependsOn(conf).
+					OnError(orchestrator.Retry(1))
+
+				plan.HandlerFunc("reload", taskFunc(true, rec.record("reload")))
+
+				attempts := 0
+				plan.HandlerFunc("restart", func(
+					_ context.Context,
+					_ *osapi.Client,
+				) (*orchestrator.Result, error) {
+					rec.record("restart")()
+					attempts++
+					if attempts == 1 {
+						return nil, fmt.Errorf("restart failed")
+					}
+
+					return &orchestrator.Result{Changed: true}, nil
+				})
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.Require().NoError(err)
+				s.Equal([]string{"reload", "restart", "restart"}, ran)
+				s.Equal(orchestrator.StatusChanged, statusMap(report)["flush"])
+			},
+		},
+		{
+			name: "retried flush with a failing handler fails the plan",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				conf := plan.TaskFunc("conf", taskFunc(true, nil)).Notify("restart")
+				flush := plan.FlushHandlers("flush").DependsOn(conf)
+				flush.OnError(orchestrator.Retry(2))
+				plan.TaskFunc("after", taskFunc(true, rec.record("after"))).
+					DependsOn(flush)
+
+				plan.HandlerFunc("restart", func(
+					_ context.Context,
+					_ *osapi.Client,
+				) (*orchestrator.Result, error) {
+					rec.record("restart")()
+
+					return nil, fmt.Errorf("restart failed")
+				})
+			},
+			validateFunc: func(report *orchestrator.Report, err error, ran []string) {
+				s.ErrorContains(err, "restart failed")
+				s.Equal(orchestrator.StatusFailed, statusMap(report)["flush"])
+				s.Equal([]string{"restart", "restart", "restart"}, ran)
+			},
+		},
+		{
+			name: "does not run handlers when the plan fails",
+			setup: func(plan *orchestrator.Plan, rec *recorder) {
+				conf := plan.TaskFunc("conf", taskFunc(true, nil)).Notify("restart")
+				plan.TaskFunc("broken", failFunc("boom")).DependsOn(conf)
+				plan.HandlerFunc("restart", taskFunc(true, rec.record("restart")))
+			},
+			validateFunc: func(_ *orchestrator.Report, err error, ran []string) {
+				s.Error(err)
+				s.Empty(ran)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			rec := &recorder{}
+
+			plan := orchestrator.NewPlan(nil)
+			tt.setup(plan, rec)
+
+			report, err := plan.Run(context.Background())
+			tt.validateFunc(report, err, rec.names)
+		})
+	}
+}
+
+// recorder records the order in which task functions run.
+type recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+// record returns a side effect that appends name when the task runs.
+func (r *recorder) record(
+	name string,
+) func() {
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.names = append(r.names, name)
+	}
+}
+
+// toAny converts a []string to []any for comparison with decoded data.
+func toAny(
+	v any,
+) []any {
+	names, _ := v.([]string)
+
+	out := make([]any, len(names))
+	for i, n := range names {
+		out[i] = n
+	}
+
+	return out
+}
+
 func (s *PlanPublicTestSuite) TestClient() {
 	client := osapi.New("http://localhost", "token")
 
@@ -1161,6 +1637,64 @@ func (s *PlanPublicTestSuite) TestValidate() {
 				s.Contains(err.Error(), "duplicate task name")
 			},
 		},
+		{
+			name: "handler with task name returns error",
+			setup: func(plan *orchestrator.Plan) {
+				plan.TaskFunc("dup", taskFunc(false, nil))
+				plan.HandlerFunc("dup", taskFunc(false, nil))
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `duplicate task name: "dup"`)
+			},
+		},
+		{
+			name: "notify of unknown handler returns error",
+			setup: func(plan *orchestrator.Plan) {
+				plan.TaskFunc("a", taskFunc(false, nil)).Notify("restart")
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `task "a" notifies unknown handler "restart"`)
+			},
+		},
+		{
+			name: "handler with dependencies returns error",
+			setup: func(plan *orchestrator.Plan) {
+				a := plan.TaskFunc("a", taskFunc(false, nil))
+				plan.HandlerFunc("restart", taskFunc(false, nil)).DependsOn(a)
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `handler "restart" cannot have dependencies`)
+			},
+		},
+		{
+			name: "handler notifying handlers returns error",
+			setup: func(plan *orchestrator.Plan) {
+				plan.HandlerFunc("reload", taskFunc(false, nil))
+				plan.HandlerFunc("restart", taskFunc(false, nil)).Notify("reload")
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `handler "restart" cannot notify handlers`)
+			},
+		},
+		{
+			name: "handler with OnlyIfChanged returns error",
+			setup: func(plan *orchestrator.Plan) {
+				plan.HandlerFunc("restart", taskFunc(false, nil)).OnlyIfChanged()
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `handler "restart" cannot use OnlyIfChanged`)
+			},
+		},
+		{
+			name: "task depending on handler returns error",
+			setup: func(plan *orchestrator.Plan) {
+				h := plan.HandlerFunc("restart", taskFunc(false, nil))
+				plan.TaskFunc("a", taskFunc(false, nil)).DependsOn(h)
+			},
+			validateFunc: func(err error) {
+				s.ErrorContains(err, `task "a" depends on handler "restart"`)
+			},
+		},
 		{
 			name: "valid plan returns nil",
 			setup: func(plan *orchestrator.Plan) {
@@ -1277,6 +1811,20 @@ func (s *PlanPublicTestSuite) TestExplain() {
 			},
 			contains: []string{"install [op]"},
 		},
+		{
+			name: "handlers, notify, and flush shown",
+			setup: func(plan *orchestrator.Plan) {
+				a := plan.TaskFunc("a", taskFunc(false, nil)).Notify("restart")
+				plan.FlushHandlers("flush").DependsOn(a)
+				plan.Handler("restart", &orchestrator.Op{Operation: "command.exec.execute"})
+				plan.HandlerFunc("reload", taskFunc(false, nil))
+			},
+			contains: []string{
+				"a [fn] (notify restart)",
+				"flush [flush] <- a",
+				"Handlers:\n  restart [op]\n  reload [fn]\n",
+			},
+		},
 		{
 			name: "guard shown in flags",
 			setup: func(plan *orchestrator.Plan) {
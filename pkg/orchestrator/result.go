@@ -49,6 +49,10 @@ type TaskResult struct {
 	Error       error
 	Data        map[string]any
 	HostResults []HostResult
+
+	// Notifiers lists the changed tasks that notified a handler, in
+	// name order. Empty for tasks that are not handlers.
+	Notifiers []string
 }
 
 // Results is a map of task name to Result, used for conditional logic.
@@ -3,8 +3,12 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
 )
 
 // runner executes a validated plan.
@@ -13,6 +17,16 @@ type runner struct {
 	results Results
 	failed  map[string]bool
 	mu      sync.Mutex
+
+	// notified maps handler names to the changed tasks that notified
+	// them since the last flush.
+	notified map[string][]string
+	// notifiers maps handler names to the notifiers of their current
+	// run, for the handler's TaskResult.
+	notifiers map[string][]string
+	// flushed holds handler results produced by flush tasks, appended
+	// to the report after the level that ran them.
+	flushed []TaskResult
 }
 
 // newRunner creates a runner for the plan.
@@ -20,9 +34,11 @@ func newRunner(
 	plan *Plan,
 ) *runner {
 	return &runner{
-		plan:    plan,
-		results: make(Results),
-		failed:  make(map[string]bool),
+		plan:      plan,
+		results:   make(Results),
+		failed:    make(map[string]bool),
+		notified:  make(map[string][]string),
+		notifiers: make(map[string][]string),
 	}
 }
 
@@ -44,6 +60,11 @@ func (r *runner) run(
 		results, err := r.runLevel(ctx, level)
 		taskResults = append(taskResults, results...)
 
+		r.mu.Lock()
+		taskResults = append(taskResults, r.flushed...)
+		r.flushed = nil
+		r.mu.Unlock()
+
 		r.callAfterLevel(i, results)
 
 		if err != nil {
@@ -58,6 +79,9 @@ func (r *runner) run(
 		}
 	}
 
+	handlerResults, err := r.runHandlers(ctx)
+	taskResults = append(taskResults, handlerResults...)
+
 	report := &Report{
 		Tasks:    taskResults,
 		Duration: time.Since(start),
@@ -65,7 +89,7 @@ func (r *runner) run(
 
 	r.callAfterPlan(report)
 
-	return report, nil
+	return report, err
 }
 
 // hook returns the plan's hooks or nil.
@@ -246,9 +270,7 @@ func (r *runner) runTask(
 					Duration: time.Since(start),
 				}
 				r.callOnSkip(t, "dependency failed")
-				r.callAfterTask(t, tr)
-
-				return tr
+				return r.finish(t, tr)
 			}
 		}
 
@@ -282,9 +304,7 @@ func (r *runner) runTask(
 			}
 
 			r.callOnSkip(t, "no dependencies changed")
-			r.callAfterTask(t, tr)
-
-			return tr
+			return r.finish(t, tr)
 		}
 	}
 
@@ -309,9 +329,7 @@ func (r *runner) runTask(
 				reason = t.guardReason
 			}
 			r.callOnSkip(t, reason)
-			r.callAfterTask(t, tr)
-
-			return tr
+			return r.finish(t, tr)
 		}
 	}
 
@@ -330,7 +348,9 @@ func (r *runner) runTask(
 	client := r.plan.client
 
 	for attempt := range maxAttempts {
-		if t.fnr != nil {
+		if t.flush {
+			result, err = r.executeFlush(ctx)
+		} else if t.fnr != nil {
 			r.mu.Lock()
 			results := r.results
 			r.mu.Unlock()
@@ -366,9 +386,7 @@ func (r *runner) runTask(
 			Error:    err,
 		}
 
-		r.callAfterTask(t, tr)
-
-		return tr
+		return r.finish(t, tr)
 	}
 
 	status := StatusUnchanged
@@ -391,11 +409,113 @@ func (r *runner) runTask(
 		HostResults: result.HostResults,
 	}
 
+	if result.Changed {
+		r.notify(t)
+	}
+
+	return r.finish(t, tr)
+}
+
+// finish records the notifiers of a handler on its result and invokes
+// the AfterTask hook.
+func (r *runner) finish(
+	t *Task,
+	tr TaskResult,
+) TaskResult {
+	r.mu.Lock()
+	tr.Notifiers = r.notifiers[t.name]
+	r.mu.Unlock()
+
 	r.callAfterTask(t, tr)
 
 	return tr
 }
 
+// notify queues the handlers a changed task notifies.
+func (r *runner) notify(
+	t *Task,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range t.notify {
+		r.notified[name] = append(r.notified[name], t.name)
+	}
+}
+
+// runHandlers runs each notified handler once, in the order the
+// handlers were added to the plan, clearing its notifications once it
+// has run. A failed handler stops the remaining ones unless its error
+// strategy is Continue; its notifications stay queued so a retried
+// flush runs it again.
+func (r *runner) runHandlers(
+	ctx context.Context,
+) ([]TaskResult, error) {
+	var results []TaskResult
+
+	for _, h := range r.plan.handlers {
+		r.mu.Lock()
+		notifiers := slices.Clone(r.notified[h.name])
+		r.mu.Unlock()
+
+		if len(notifiers) == 0 {
+			continue
+		}
+
+		sort.Strings(notifiers)
+
+		r.mu.Lock()
+		r.notifiers[h.name] = notifiers
+		r.mu.Unlock()
+
+		tr := r.runTask(ctx, h)
+		results = append(results, tr)
+
+		if tr.Status == StatusFailed && r.effectiveStrategy(h).kind != "continue" {
+			return results, tr.Error
+		}
+
+		// Keep notifications queued by tasks that ran alongside.
+		r.mu.Lock()
+		r.notified[h.name] = r.notified[h.name][len(notifiers):]
+		if len(r.notified[h.name]) == 0 {
+			delete(r.notified, h.name)
+		}
+		r.mu.Unlock()
+	}
+
+	return results, nil
+}
+
+// executeFlush runs the handlers notified so far for a flush task. The
+// handler results are added to the report after the flush task's level.
+func (r *runner) executeFlush(
+	ctx context.Context,
+) (*Result, error) {
+	results, err := r.runHandlers(ctx)
+
+	r.mu.Lock()
+	r.flushed = append(r.flushed, results...)
+	r.mu.Unlock()
+
+	ran := make([]string, 0, len(results))
+	changed := false
+
+	for _, tr := range results {
+		ran = append(ran, tr.Name)
+		changed = changed || tr.Changed
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Changed: changed,
+		Data:    map[string]any{"handlers": ran},
+	}, nil
+}
+
 // DefaultPollInterval is the interval between job status polls.
 var DefaultPollInterval = 500 * time.Millisecond
 
@@ -466,6 +586,13 @@ func (r *runner) executeOp(
 		)
 	}
 
+	switch op.Operation {
+	case "agent.drain":
+		return r.executeDrain(ctx, op)
+	case "agent.undrain":
+		return r.executeUndrain(ctx, op)
+	}
+
 	operation := map[string]interface{}{
 		"type": op.Operation,
 	}
@@ -506,49 +633,107 @@ func (r *runner) executeOp(
 	return result, nil
 }
 
-// pollJob polls a job until it reaches a terminal state.
-func (r *runner) pollJob(
+// executeDrain drains the agent named by op.Target and waits for its
+// in-flight jobs to finish. Changed is false if it was already drained.
+func (r *runner) executeDrain(
 	ctx context.Context,
-	jobID string,
+	op *Op,
 ) (*Result, error) {
-	ticker := time.NewTicker(DefaultPollInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-ticker.C:
-			resp, err := r.plan.client.Job.Get(ctx, jobID)
-			if err != nil {
-				return nil, fmt.Errorf("poll job %s: %w", jobID, err)
-			}
+	if err := requireHostTarget(op); err != nil {
+		return nil, err
+	}
 
-			job := resp.Data
+	resp, err := r.plan.client.Agent.DrainAndWait(
+		ctx,
+		op.Target,
+		osapi.WithPollInterval(DefaultPollInterval, DefaultPollInterval),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("drain agent: %w", err)
+	}
 
-			switch job.Status {
-			case "completed":
-				data := make(map[string]any)
-				if job.Result != nil {
-					if m, ok := job.Result.(map[string]any); ok {
-						data = m
-					}
-				}
+	return &Result{
+		Changed: !resp.Data.AlreadyDrained,
+		Data: map[string]any{
+			"state":        resp.Data.State,
+			"jobs_awaited": len(resp.Data.JobsAwaited),
+		},
+	}, nil
+}
 
-				changed, _ := data["changed"].(bool)
-				delete(data, "changed")
+// executeUndrain resumes job acceptance on the agent named by
+// op.Target. Changed is false if it was already accepting jobs.
+func (r *runner) executeUndrain(
+	ctx context.Context,
+	op *Op,
+) (*Result, error) {
+	if err := requireHostTarget(op); err != nil {
+		return nil, err
+	}
 
-				return &Result{Changed: changed, Data: data}, nil
-			case "failed":
-				errMsg := "job failed"
-				if job.Error != "" {
-					errMsg = job.Error
-				}
+	client := r.plan.client
 
-				return nil, fmt.Errorf("job %s: %s", jobID, errMsg)
-			}
+	agent, err := client.Agent.Get(ctx, op.Target)
+	if err != nil {
+		return nil, fmt.Errorf("undrain agent: %w", err)
+	}
+
+	if agent.Data.State == osapi.AgentStateReady {
+		return &Result{
+			Changed: false,
+			Data:    map[string]any{"state": agent.Data.State},
+		}, nil
+	}
+
+	if _, err := client.Agent.Undrain(ctx, op.Target); err != nil {
+		return nil, fmt.Errorf("undrain agent: %w", err)
+	}
+
+	return &Result{
+		Changed: true,
+		Data:    map[string]any{"state": osapi.AgentStateReady},
+	}, nil
+}
+
+// requireHostTarget checks an agent operation targets one hostname.
+func requireHostTarget(
+	op *Op,
+) error {
+	if op.Target == "" || op.Target == "_any" || IsBroadcastTarget(op.Target) {
+		return fmt.Errorf(
+			"op task %q requires a single hostname target",
+			op.Operation,
+		)
+	}
+
+	return nil
+}
+
+// pollJob polls a job until it reaches a terminal state.
+func (r *runner) pollJob(
+	ctx context.Context,
+	jobID string,
+) (*Result, error) {
+	resp, err := r.plan.client.Job.Wait(
+		ctx,
+		jobID,
+		osapi.WithPollInterval(DefaultPollInterval, DefaultPollInterval),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]any)
+	if resp.Data.Result != nil {
+		if m, ok := resp.Data.Result.(map[string]any); ok {
+			data = m
 		}
 	}
+
+	changed, _ := data["changed"].(bool)
+	delete(data, "changed")
+
+	return &Result{Changed: changed, Data: data}, nil
 }
 
 // levelize groups tasks into levels where all tasks in a level can
@@ -43,6 +43,8 @@ type Task struct {
 	guardReason    string
 	requiresChange bool
 	errorStrategy  *ErrorStrategy
+	notify         []string
+	flush          bool
 }
 
 // NewTask creates a declarative task wrapping an SDK operation.
@@ -117,6 +119,28 @@ func (t *Task) DependsOn(
 	return t
 }
 
+// Notify names handlers to run if this task reports Changed. Each
+// notified handler runs once, at the next flush task or after every
+// other task has finished, however many tasks notify it. Returns the
+// task for chaining.
+func (t *Task) Notify(
+	handlers ...string,
+) *Task {
+	t.notify = append(t.notify, handlers...)
+
+	return t
+}
+
+// Notifies returns the names of the handlers this task notifies.
+func (t *Task) Notifies() []string {
+	return t.notify
+}
+
+// IsFlush returns true if this task runs notified handlers.
+func (t *Task) IsFlush() bool {
+	return t.flush
+}
+
 // Dependencies returns the task's dependencies.
 func (t *Task) Dependencies() []*Task {
 	return t.deps
@@ -0,0 +1,107 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+// ValidateTemplates renders every template deploy task and handler
+// against the facts of each agent its target resolves to, before
+// anything runs. A task is checked when its operation is "file.deploy.execute" with
+// content_type "template" and its object_name was registered with
+// WithTemplates or is uploaded by a File resource, whose Local file is
+// read; other tasks are skipped. Errors from all tasks and hosts are
+// joined.
+func (p *Plan) ValidateTemplates(
+	ctx context.Context,
+) error {
+	var (
+		tasks    []*Task
+		contents []string
+		errs     []error
+	)
+
+	all := make([]*Task, 0, len(p.tasks)+len(p.handlers))
+	all = append(all, p.tasks...)
+	all = append(all, p.handlers...)
+
+	for _, t := range all {
+		content, ok, err := p.templateFor(t)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("task %q: %w", t.name, err))
+		case ok:
+			tasks = append(tasks, t)
+			contents = append(contents, content)
+		}
+	}
+
+	if len(tasks) == 0 {
+		return errors.Join(errs...)
+	}
+
+	resp, err := p.client.Agent.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list agents: %w", err)
+	}
+
+	for i, t := range tasks {
+		content := contents[i]
+		vars, _ := t.op.Params["vars"].(map[string]any)
+
+		agents := osapi.MatchAgents(resp.Data.Agents, t.op.Target)
+		if len(agents) == 0 {
+			errs = append(errs, fmt.Errorf(
+				"task %q: no agents match target %q", t.name, t.op.Target,
+			))
+
+			continue
+		}
+
+		for _, a := range agents {
+			if err := osapi.ValidateTemplate(content, vars, &a); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"task %q on %s: %w", t.name, a.Hostname, err,
+				))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// templateFor returns the template content deployed by t, preferring
+// content registered with WithTemplates over a File resource's Local
+// file.
+func (p *Plan) templateFor(
+	t *Task,
+) (string, bool, error) {
+	if t.op == nil || t.op.Operation != "file.deploy.execute" {
+		return "", false, nil
+	}
+
+	if ct, _ := t.op.Params["content_type"].(string); ct != "template" {
+		return "", false, nil
+	}
+
+	name, _ := t.op.Params["object_name"].(string)
+	if content, ok := p.config.Templates[name]; ok {
+		return content, true, nil
+	}
+
+	local, ok := p.templateFiles[name]
+	if !ok {
+		return "", false, nil
+	}
+
+	data, err := os.ReadFile(local)
+	if err != nil {
+		return "", false, fmt.Errorf("read template: %w", err)
+	}
+
+	return string(data), true, nil
+}
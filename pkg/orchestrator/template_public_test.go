@@ -0,0 +1,194 @@
+package orchestrator_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/orchestrator"
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type TemplatePublicTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplatePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(TemplatePublicTestSuite))
+}
+
+func (s *TemplatePublicTestSuite) deployOp(
+	target string,
+	objectName string,
+) *orchestrator.Op {
+	return &orchestrator.Op{
+		Operation: "file.deploy.execute",
+		Target:    target,
+		Params: map[string]any{
+			"object_name":  objectName,
+			"path":         "/etc/app.conf",
+			"content_type": "template",
+			"vars":         map[string]any{"listen": ":8080"},
+		},
+	}
+}
+
+func (s *TemplatePublicTestSuite) TestValidateTemplates() {
+	templates := map[string]string{
+		"app.conf.tmpl":  "listen = {{ .Vars.listen }}\nworkers = {{ .Facts.cpu_count }}\n",
+		"bad.conf.tmpl":  "{{ .Vars.listen ",
+		"port.conf.tmpl": "port = {{ .Vars.port }}\n",
+	}
+
+	tests := []struct {
+		name         string
+		ops          []*orchestrator.Op
+		handlers     []*orchestrator.Op
+		validateFunc func(err error, calls []string)
+	}{
+		{
+			name: "when every host has the facts returns nil",
+			ops:  []*orchestrator.Op{s.deployOp("role:web", "app.conf.tmpl")},
+			validateFunc: func(err error, _ []string) {
+				s.NoError(err)
+			},
+		},
+		{
+			name: "when a host lacks a fact reports that host",
+			ops:  []*orchestrator.Op{s.deployOp("_all", "app.conf.tmpl")},
+			validateFunc: func(err error, _ []string) {
+				s.Require().Error(err)
+				s.Contains(err.Error(), `task "deploy-0" on db-01`)
+				s.NotContains(err.Error(), "web-01")
+			},
+		},
+		{
+			name: "when a var is missing reports every host",
+			ops:  []*orchestrator.Op{s.deployOp("role:web", "port.conf.tmpl")},
+			validateFunc: func(err error, _ []string) {
+				s.Require().Error(err)
+				s.Contains(err.Error(), "on web-01")
+				s.Contains(err.Error(), "on web-02")
+			},
+		},
+		{
+			name: "when template is malformed returns parse error",
+			ops:  []*orchestrator.Op{s.deployOp("web-01", "bad.conf.tmpl")},
+			validateFunc: func(err error, _ []string) {
+				s.Require().Error(err)
+				s.Contains(err.Error(), "parse template")
+			},
+		},
+		{
+			name: "when no agent matches the target returns error",
+			ops:  []*orchestrator.Op{s.deployOp("web-09", "app.conf.tmpl")},
+			validateFunc: func(err error, _ []string) {
+				s.Require().Error(err)
+				s.Contains(err.Error(), `no agents match target "web-09"`)
+			},
+		},
+		{
+			name:     "when a handler template lacks a fact reports the handler",
+			handlers: []*orchestrator.Op{s.deployOp("_all", "app.conf.tmpl")},
+			validateFunc: func(err error, _ []string) {
+				s.Require().Error(err)
+				s.Contains(err.Error(), `task "handler-0" on db-01`)
+			},
+		},
+		{
+			name: "when no task deploys a registered template skips listing agents",
+			ops: []*orchestrator.Op{
+				s.deployOp("_all", "unknown.tmpl"),
+				{Operation: "node.hostname.get", Target: "_any"},
+			},
+			validateFunc: func(err error, calls []string) {
+				s.NoError(err)
+				s.Empty(calls)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			srv, calls := s.agentServer()
+			defer srv.Close()
+
+			plan := orchestrator.NewPlan(
+				osapi.New(srv.URL, "test-token"),
+				orchestrator.WithTemplates(templates),
+			)
+			for i, op := range tc.ops {
+				plan.Task(fmt.Sprintf("deploy-%d", i), op)
+			}
+			for i, op := range tc.handlers {
+				plan.Handler(fmt.Sprintf("handler-%d", i), op)
+			}
+
+			err := plan.ValidateTemplates(context.Background())
+			tc.validateFunc(err, calls())
+		})
+	}
+}
+
+// agentServer serves GET /agent with two web agents that report a
+// cpu_count fact and a db agent that does not.
+func (s *TemplatePublicTestSuite) agentServer() (*httptest.Server, func() []string) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/agent" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"agents": []map[string]any{
+				{
+					"hostname": "web-01",
+					"status":   "Ready",
+					"labels":   map[string]string{"role": "web"},
+					"facts":    map[string]any{"cpu_count": 4},
+				},
+				{
+					"hostname": "web-02",
+					"status":   "Ready",
+					"labels":   map[string]string{"role": "web"},
+					"facts":    map[string]any{"cpu_count": 8},
+				},
+				{
+					"hostname": "db-01",
+					"status":   "Ready",
+					"labels":   map[string]string{"role": "db"},
+				},
+			},
+			"total": 3,
+		})
+	}))
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), calls...)
+	}
+}
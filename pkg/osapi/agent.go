@@ -23,6 +23,7 @@ package osapi
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/osapi-io/osapi-sdk/pkg/osapi/gen"
 )
@@ -35,6 +36,8 @@ type MessageResponse struct {
 // AgentService provides agent discovery and details operations.
 type AgentService struct {
 	client *gen.ClientWithResponses
+	jobs   *JobService
+	cache  *responseCache
 }
 
 // List retrieves all active agents.
@@ -64,6 +67,23 @@ func (s *AgentService) List(
 func (s *AgentService) Get(
 	ctx context.Context,
 	hostname string,
+) (*Response[Agent], error) {
+	key := cacheKey{op: "agent.get", target: hostname}
+	if result, ok := cacheLookup[Agent](s.cache, key); ok {
+		return result, nil
+	}
+
+	result, err := s.get(ctx, hostname)
+	s.cache.store(key, result, err)
+
+	return result, err
+}
+
+// get retrieves an agent without consulting the cache, for callers
+// that poll for state changes.
+func (s *AgentService) get(
+	ctx context.Context,
+	hostname string,
 ) (*Response[Agent], error) {
 	resp, err := s.client.GetAgentDetailsWithResponse(ctx, hostname)
 	if err != nil {
@@ -90,6 +110,8 @@ func (s *AgentService) Drain(
 	ctx context.Context,
 	hostname string,
 ) (*Response[MessageResponse], error) {
+	defer s.cache.invalidate(hostname)
+
 	resp, err := s.client.DrainAgentWithResponse(ctx, hostname)
 	if err != nil {
 		return nil, fmt.Errorf("drain agent %s: %w", hostname, err)
@@ -118,6 +140,8 @@ func (s *AgentService) Undrain(
 	ctx context.Context,
 	hostname string,
 ) (*Response[MessageResponse], error) {
+	defer s.cache.invalidate(hostname)
+
 	resp, err := s.client.UndrainAgentWithResponse(ctx, hostname)
 	if err != nil {
 		return nil, fmt.Errorf("undrain agent %s: %w", hostname, err)
@@ -140,3 +164,29 @@ func (s *AgentService) Undrain(
 
 	return NewResponse(msg, resp.Body), nil
 }
+
+// MatchAgents returns the agents a routing target resolves to: a
+// hostname, a "key:value" label selector, or "_all". "_any" may route
+// to any agent, so it matches all of them.
+func MatchAgents(
+	agents []Agent,
+	target string,
+) []Agent {
+	if target == "_all" || target == "_any" {
+		return agents
+	}
+
+	var matched []Agent
+
+	key, value, isLabel := strings.Cut(target, ":")
+	for _, a := range agents {
+		switch {
+		case isLabel && a.Labels[key] == value:
+			matched = append(matched, a)
+		case !isLabel && a.Hostname == target:
+			matched = append(matched, a)
+		}
+	}
+
+	return matched
+}
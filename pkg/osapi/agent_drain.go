@@ -0,0 +1,131 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Agent scheduling states reported in Agent.State.
+const (
+	AgentStateReady    = "Ready"
+	AgentStateDraining = "Draining"
+	AgentStateCordoned = "Cordoned"
+)
+
+// DrainResult summarizes a DrainAndWait call.
+type DrainResult struct {
+	// Hostname is the drained agent.
+	Hostname string
+
+	// AlreadyDrained is true if the agent was draining or cordoned
+	// before the call, so no drain request was sent.
+	AlreadyDrained bool
+
+	// State is the agent state observed at the last poll.
+	State string
+
+	// JobsAwaited are the IDs of processing jobs seen on the agent
+	// while waiting.
+	JobsAwaited []string
+
+	// Polls is the number of agent and job list polls made.
+	Polls int
+
+	// Duration is the time spent draining and waiting.
+	Duration time.Duration
+}
+
+// DrainAndWait drains an agent and polls its state and the job list
+// until it no longer accepts jobs and none of its jobs are processing.
+// Only WithPollInterval applies from opts.
+func (s *AgentService) DrainAndWait(
+	ctx context.Context,
+	hostname string,
+	opts ...WaitOption,
+) (*Response[DrainResult], error) {
+	start := time.Now()
+	options := newWaitOptions(opts)
+	result := DrainResult{Hostname: hostname}
+
+	agent, err := s.get(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	if agent.Data.State == AgentStateDraining || agent.Data.State == AgentStateCordoned {
+		result.AlreadyDrained = true
+	} else if _, err := s.Drain(ctx, hostname); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	interval := options.interval
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		result.Polls++
+
+		agent, err := s.get(ctx, hostname)
+		if err != nil {
+			return nil, fmt.Errorf("poll agent %s: %w", hostname, err)
+		}
+
+		result.State = agent.Data.State
+
+		processing := 0
+
+		for job, err := range s.jobs.All(ctx, ListParams{
+			Status:   JobStatusProcessing,
+			Hostname: hostname,
+		}) {
+			if err != nil {
+				return nil, fmt.Errorf("poll jobs on %s: %w", hostname, err)
+			}
+
+			processing++
+
+			if !seen[job.ID] {
+				seen[job.ID] = true
+				result.JobsAwaited = append(result.JobsAwaited, job.ID)
+			}
+		}
+
+		if processing == 0 && result.State != AgentStateReady {
+			result.Duration = time.Since(start)
+
+			return NewResponse(result, nil), nil
+		}
+
+		timer.Reset(interval)
+		interval = min(interval*2, options.maxInterval)
+	}
+}
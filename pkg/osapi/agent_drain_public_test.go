@@ -0,0 +1,240 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type AgentDrainPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+}
+
+func (suite *AgentDrainPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+// drainServer fakes the agent and job endpoints for web-01. Each agent
+// GET serves the next state and each job list serves the next set of
+// processing job IDs, repeating the last once exhausted.
+type drainServer struct {
+	*httptest.Server
+
+	drains atomic.Int32
+}
+
+func newDrainServer(
+	states []string,
+	processing [][]string,
+	drainCode int,
+) *drainServer {
+	s := &drainServer{}
+
+	var stateIdx, jobIdx atomic.Int32
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/agent/web-01":
+			i := min(int(stateIdx.Add(1))-1, len(states)-1)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"hostname": "web-01",
+				"status":   "Ready",
+				"state":    states[i],
+			})
+		case "/agent/web-01/drain":
+			s.drains.Add(1)
+			w.WriteHeader(drainCode)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"message": "drain initiated",
+				"error":   "conflict",
+			})
+		case "/job":
+			i := min(int(jobIdx.Add(1))-1, len(processing)-1)
+
+			items := make([]map[string]any, 0, len(processing[i]))
+			for _, id := range processing[i] {
+				items = append(items, map[string]any{
+					"id":       id,
+					"status":   r.URL.Query().Get("status"),
+					"hostname": "web-01",
+				})
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items":       items,
+				"total_items": len(items),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+
+	return s
+}
+
+func (suite *AgentDrainPublicTestSuite) TestDrainAndWait() {
+	const (
+		job1 = "00000000-0000-0000-0000-000000000001"
+		job2 = "00000000-0000-0000-0000-000000000002"
+	)
+
+	tests := []struct {
+		name         string
+		hostname     string
+		states       []string
+		processing   [][]string
+		drainCode    int
+		timeout      time.Duration
+		validateFunc func(*osapi.Response[osapi.DrainResult], error, *drainServer)
+	}{
+		{
+			name:       "when jobs finish returns summary",
+			hostname:   "web-01",
+			states:     []string{"Ready", "Draining", "Draining", "Cordoned"},
+			processing: [][]string{{job1, job2}, {job2}, {}},
+			drainCode:  http.StatusOK,
+			validateFunc: func(
+				resp *osapi.Response[osapi.DrainResult],
+				err error,
+				s *drainServer,
+			) {
+				suite.NoError(err)
+				suite.False(resp.Data.AlreadyDrained)
+				suite.Equal("Cordoned", resp.Data.State)
+				suite.Equal([]string{job1, job2}, resp.Data.JobsAwaited)
+				suite.Equal(3, resp.Data.Polls)
+				suite.Equal(int32(1), s.drains.Load())
+			},
+		},
+		{
+			name:       "when agent is already draining skips drain request",
+			hostname:   "web-01",
+			states:     []string{"Draining"},
+			processing: [][]string{{}},
+			drainCode:  http.StatusOK,
+			validateFunc: func(
+				resp *osapi.Response[osapi.DrainResult],
+				err error,
+				s *drainServer,
+			) {
+				suite.NoError(err)
+				suite.True(resp.Data.AlreadyDrained)
+				suite.Empty(resp.Data.JobsAwaited)
+				suite.Zero(s.drains.Load())
+			},
+		},
+		{
+			name:       "when drain conflicts returns ConflictError",
+			hostname:   "web-01",
+			states:     []string{"Ready"},
+			processing: [][]string{{}},
+			drainCode:  http.StatusConflict,
+			validateFunc: func(
+				resp *osapi.Response[osapi.DrainResult],
+				err error,
+				_ *drainServer,
+			) {
+				suite.Nil(resp)
+
+				var target *osapi.ConflictError
+				suite.True(errors.As(err, &target))
+			},
+		},
+		{
+			name:      "when agent is unknown returns NotFoundError",
+			hostname:  "db-01",
+			states:    []string{"Ready"},
+			drainCode: http.StatusOK,
+			validateFunc: func(
+				resp *osapi.Response[osapi.DrainResult],
+				err error,
+				_ *drainServer,
+			) {
+				suite.Nil(resp)
+
+				var target *osapi.NotFoundError
+				suite.True(errors.As(err, &target))
+			},
+		},
+		{
+			name:       "when jobs never finish returns context error",
+			hostname:   "web-01",
+			states:     []string{"Ready", "Draining"},
+			processing: [][]string{{job1}},
+			drainCode:  http.StatusOK,
+			timeout:    20 * time.Millisecond,
+			validateFunc: func(
+				resp *osapi.Response[osapi.DrainResult],
+				err error,
+				_ *drainServer,
+			) {
+				suite.Nil(resp)
+				suite.ErrorIs(err, context.DeadlineExceeded)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := newDrainServer(tc.states, tc.processing, tc.drainCode)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			ctx := suite.ctx
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			resp, err := sut.Agent.DrainAndWait(
+				ctx,
+				tc.hostname,
+				osapi.WithPollInterval(time.Millisecond, time.Millisecond),
+			)
+			tc.validateFunc(resp, err, server)
+		})
+	}
+}
+
+func TestAgentDrainPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(AgentDrainPublicTestSuite))
+}
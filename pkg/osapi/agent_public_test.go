@@ -433,6 +433,56 @@ func (suite *AgentPublicTestSuite) TestUndrain() {
 	}
 }
 
+func (suite *AgentPublicTestSuite) TestMatchAgents() {
+	agents := []osapi.Agent{
+		{Hostname: "web-01", Labels: map[string]string{"role": "web"}},
+		{Hostname: "web-02", Labels: map[string]string{"role": "web"}},
+		{Hostname: "db-01", Labels: map[string]string{"role": "db"}},
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{
+			name:   "when target is _all matches every agent",
+			target: "_all",
+			want:   []string{"web-01", "web-02", "db-01"},
+		},
+		{
+			name:   "when target is _any matches every agent",
+			target: "_any",
+			want:   []string{"web-01", "web-02", "db-01"},
+		},
+		{
+			name:   "when target is a label matches labelled agents",
+			target: "role:web",
+			want:   []string{"web-01", "web-02"},
+		},
+		{
+			name:   "when target is a hostname matches that agent",
+			target: "db-01",
+			want:   []string{"db-01"},
+		},
+		{
+			name:   "when nothing matches returns none",
+			target: "role:cache",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var got []string
+			for _, a := range osapi.MatchAgents(agents, tc.target) {
+				got = append(got, a.Hostname)
+			}
+
+			suite.Equal(tc.want, got)
+		})
+	}
+}
+
 func TestAgentPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(AgentPublicTestSuite))
 }
@@ -0,0 +1,232 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultWatchInterval is the polling interval Watch uses when given a
+// non-positive interval.
+const DefaultWatchInterval = 5 * time.Second
+
+// AgentEventType identifies the kind of change reported by Watch.
+type AgentEventType string
+
+// Agent event types.
+const (
+	AgentAdded            AgentEventType = "added"
+	AgentRemoved          AgentEventType = "removed"
+	AgentStateChanged     AgentEventType = "state_changed"
+	AgentConditionChanged AgentEventType = "condition_changed"
+	AgentLabelsChanged    AgentEventType = "labels_changed"
+)
+
+// AgentEvent is a change between two successive agent snapshots.
+type AgentEvent struct {
+	// Type is the kind of change.
+	Type AgentEventType
+
+	// Hostname is the agent the event refers to.
+	Hostname string
+
+	// Agent is the current agent, or the last seen agent for
+	// AgentRemoved.
+	Agent Agent
+
+	// Previous is the agent from the prior snapshot. Nil for AgentAdded.
+	Previous *Agent
+
+	// Condition is the condition that changed for AgentConditionChanged.
+	// Status is false when the condition is no longer reported.
+	Condition Condition
+}
+
+// Watch polls List every interval and yields an event for each change
+// between successive snapshots. The first snapshot yields AgentAdded
+// for every agent. Poll errors are yielded and polling continues with
+// the previous snapshot; the iterator ends when ctx is done or the
+// caller stops iterating.
+func (s *AgentService) Watch(
+	ctx context.Context,
+	interval time.Duration,
+) iter.Seq2[AgentEvent, error] {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	return func(yield func(AgentEvent, error) bool) {
+		var prev map[string]Agent
+
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			resp, err := s.List(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				if !yield(AgentEvent{}, err) {
+					return
+				}
+			} else {
+				next := make(map[string]Agent, len(resp.Data.Agents))
+				for _, a := range resp.Data.Agents {
+					next[a.Hostname] = a
+				}
+
+				for _, e := range diffAgents(prev, next) {
+					if !yield(e, nil) {
+						return
+					}
+				}
+
+				prev = next
+			}
+
+			timer.Reset(interval)
+		}
+	}
+}
+
+// diffAgents returns the events between two snapshots keyed by
+// hostname, ordered by hostname.
+func diffAgents(
+	prev map[string]Agent,
+	next map[string]Agent,
+) []AgentEvent {
+	var events []AgentEvent
+
+	hosts := slices.Sorted(maps.Keys(next))
+	for h := range prev {
+		if _, ok := next[h]; !ok {
+			hosts = append(hosts, h)
+		}
+	}
+
+	slices.Sort(hosts)
+
+	for _, h := range hosts {
+		old, existed := prev[h]
+		cur, exists := next[h]
+
+		switch {
+		case !existed:
+			events = append(events, AgentEvent{
+				Type:     AgentAdded,
+				Hostname: h,
+				Agent:    cur,
+			})
+
+			continue
+		case !exists:
+			events = append(events, AgentEvent{
+				Type:     AgentRemoved,
+				Hostname: h,
+				Agent:    old,
+				Previous: &old,
+			})
+
+			continue
+		}
+
+		if old.Status != cur.Status || old.State != cur.State {
+			events = append(events, AgentEvent{
+				Type:     AgentStateChanged,
+				Hostname: h,
+				Agent:    cur,
+				Previous: &old,
+			})
+		}
+
+		for _, c := range diffConditions(old.Conditions, cur.Conditions) {
+			events = append(events, AgentEvent{
+				Type:      AgentConditionChanged,
+				Hostname:  h,
+				Agent:     cur,
+				Previous:  &old,
+				Condition: c,
+			})
+		}
+
+		if !maps.Equal(old.Labels, cur.Labels) {
+			events = append(events, AgentEvent{
+				Type:     AgentLabelsChanged,
+				Hostname: h,
+				Agent:    cur,
+				Previous: &old,
+			})
+		}
+	}
+
+	return events
+}
+
+// diffConditions returns the conditions whose status changed, ordered
+// by type. A condition that is not reported counts as false, so one
+// that disappears while true is returned with Status false.
+func diffConditions(
+	old []Condition,
+	cur []Condition,
+) []Condition {
+	before := make(map[string]Condition, len(old))
+	for _, c := range old {
+		before[c.Type] = c
+	}
+
+	after := make(map[string]Condition, len(cur))
+	for _, c := range cur {
+		after[c.Type] = c
+	}
+
+	var changed []Condition
+
+	for _, c := range cur {
+		if before[c.Type].Status != c.Status {
+			changed = append(changed, c)
+		}
+	}
+
+	for _, c := range old {
+		if _, ok := after[c.Type]; !ok && c.Status {
+			changed = append(changed, Condition{Type: c.Type})
+		}
+	}
+
+	slices.SortFunc(changed, func(a, b Condition) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+
+	return changed
+}
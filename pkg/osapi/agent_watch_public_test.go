@@ -0,0 +1,180 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type AgentWatchPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+}
+
+func (suite *AgentWatchPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+// snapshotServer serves successive agent list bodies, repeating the
+// last one once exhausted, and counts requests in calls. An empty body
+// returns 500.
+func snapshotServer(
+	bodies []string,
+	calls *atomic.Int32,
+) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) {
+		i := min(int(calls.Add(1))-1, len(bodies)-1)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if bodies[i] == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"boom"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(bodies[i]))
+	}))
+}
+
+func (suite *AgentWatchPublicTestSuite) TestWatch() {
+	empty := `{"agents":[],"total":0}`
+
+	tests := []struct {
+		name     string
+		bodies   []string
+		interval time.Duration
+		timeout  time.Duration
+		// maxEvents stops watching after that many events; 0 watches
+		// until the context ends.
+		maxEvents    int
+		validateFunc func(events []string, errs int, calls int32)
+	}{
+		{
+			name: "when the fleet changes yields events and poll errors",
+			bodies: []string{
+				`{"agents":[{"hostname":"web-01","status":"Ready","state":"Ready"}],"total":1}`,
+				``,
+				`{"agents":[{"hostname":"web-01","status":"Ready","state":"Draining"},` +
+					`{"hostname":"web-02","status":"Ready"}],"total":2}`,
+				`{"agents":[{"hostname":"web-02","status":"Ready",` +
+					`"conditions":[{"type":"HighLoad","status":true,` +
+					`"last_transition_time":"2026-01-01T00:00:00Z"}]}],"total":1}`,
+			},
+			interval:  time.Millisecond,
+			maxEvents: 5,
+			validateFunc: func(events []string, errs int, _ int32) {
+				suite.Equal(1, errs)
+				suite.Equal([]string{
+					"added:web-01",
+					"state_changed:web-01",
+					"added:web-02",
+					"removed:web-01",
+					"condition_changed:web-02",
+				}, events)
+			},
+		},
+		{
+			name:     "when context is canceled stops without events",
+			bodies:   []string{empty},
+			interval: time.Millisecond,
+			timeout:  20 * time.Millisecond,
+			validateFunc: func(events []string, errs int, calls int32) {
+				suite.Empty(events)
+				suite.Zero(errs)
+				suite.Greater(calls, int32(1))
+			},
+		},
+		{
+			name:     "when interval is zero polls at the default interval",
+			bodies:   []string{empty},
+			interval: 0,
+			timeout:  50 * time.Millisecond,
+			validateFunc: func(_ []string, _ int, calls int32) {
+				suite.Equal(int32(1), calls)
+			},
+		},
+		{
+			name:     "when interval is negative polls at the default interval",
+			bodies:   []string{empty},
+			interval: -time.Second,
+			timeout:  50 * time.Millisecond,
+			validateFunc: func(_ []string, _ int, calls int32) {
+				suite.Equal(int32(1), calls)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var calls atomic.Int32
+			server := snapshotServer(tc.bodies, &calls)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			ctx := suite.ctx
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			var (
+				events []string
+				errs   int
+			)
+
+			for e, err := range sut.Agent.Watch(ctx, tc.interval) {
+				if err != nil {
+					errs++
+
+					continue
+				}
+
+				events = append(events, string(e.Type)+":"+e.Hostname)
+				if len(events) == tc.maxEvents {
+					break
+				}
+			}
+
+			tc.validateFunc(events, errs, calls.Load())
+		})
+	}
+}
+
+func TestAgentWatchPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(AgentWatchPublicTestSuite))
+}
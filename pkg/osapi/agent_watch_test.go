@@ -0,0 +1,125 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AgentWatchTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AgentWatchTestSuite) TestDiffAgents() {
+	ready := Agent{
+		Hostname: "web-01",
+		Status:   "Ready",
+		State:    "Ready",
+		Labels:   map[string]string{"group": "web"},
+		Conditions: []Condition{
+			{Type: "DiskPressure", Status: false},
+			{Type: "HighLoad", Status: true},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		prev         map[string]Agent
+		next         map[string]Agent
+		validateFunc func([]AgentEvent)
+	}{
+		{
+			name: "when first snapshot reports every agent as added",
+			next: map[string]Agent{"web-02": {Hostname: "web-02"}, "web-01": ready},
+			validateFunc: func(events []AgentEvent) {
+				suite.Len(events, 2)
+				suite.Equal(AgentAdded, events[0].Type)
+				suite.Equal("web-01", events[0].Hostname)
+				suite.Nil(events[0].Previous)
+				suite.Equal("web-02", events[1].Hostname)
+			},
+		},
+		{
+			name: "when snapshots are equal reports nothing",
+			prev: map[string]Agent{"web-01": ready},
+			next: map[string]Agent{"web-01": ready},
+			validateFunc: func(events []AgentEvent) {
+				suite.Empty(events)
+			},
+		},
+		{
+			name: "when agent disappears reports removed with last state",
+			prev: map[string]Agent{"web-01": ready},
+			next: map[string]Agent{},
+			validateFunc: func(events []AgentEvent) {
+				suite.Len(events, 1)
+				suite.Equal(AgentRemoved, events[0].Type)
+				suite.Equal(ready.Labels, events[0].Agent.Labels)
+				suite.NotNil(events[0].Previous)
+			},
+		},
+		{
+			name: "when state, conditions, and labels change reports each",
+			prev: map[string]Agent{"web-01": ready},
+			next: map[string]Agent{"web-01": {
+				Hostname: "web-01",
+				Status:   "Ready",
+				State:    "Draining",
+				Labels:   map[string]string{"group": "canary"},
+				Conditions: []Condition{
+					{Type: "DiskPressure", Status: true, Reason: "95% used"},
+					{Type: "MemoryPressure", Status: false},
+				},
+			}},
+			validateFunc: func(events []AgentEvent) {
+				types := make([]AgentEventType, 0, len(events))
+				for _, e := range events {
+					types = append(types, e.Type)
+				}
+
+				suite.Equal([]AgentEventType{
+					AgentStateChanged,
+					AgentConditionChanged,
+					AgentConditionChanged,
+					AgentLabelsChanged,
+				}, types)
+				suite.Equal("Ready", events[0].Previous.State)
+				suite.Equal("Draining", events[0].Agent.State)
+				suite.Equal("DiskPressure", events[1].Condition.Type)
+				suite.True(events[1].Condition.Status)
+				suite.Equal("HighLoad", events[2].Condition.Type)
+				suite.False(events[2].Condition.Status)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(diffAgents(tc.prev, tc.next))
+		})
+	}
+}
+
+func TestAgentWatchTestSuite(t *testing.T) {
+	suite.Run(t, new(AgentWatchTestSuite))
+}
@@ -22,7 +22,11 @@ package osapi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"iter"
+	"net/http"
 
 	"github.com/google/uuid"
 
@@ -70,6 +74,82 @@ func (s *AuditService) List(
 	return NewResponse(auditListFromGen(resp.JSON200), resp.Body), nil
 }
 
+// All returns an iterator over every audit log entry, fetching pages
+// transparently. The iteration stops at the first error, which is
+// yielded with a zero AuditEntry.
+func (s *AuditService) All(
+	ctx context.Context,
+	opts ...PageOption,
+) iter.Seq2[AuditEntry, error] {
+	fetch := func(
+		ctx context.Context,
+		limit int,
+		offset int,
+	) ([]AuditEntry, int, error) {
+		resp, err := s.List(ctx, limit, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return resp.Data.Items, resp.Data.TotalItems, nil
+	}
+
+	return paginate(ctx, fetch, 0, 0, opts...)
+}
+
+// Query returns an iterator over the audit entries matching q. The
+// server only supports pagination, so every page is fetched and all
+// filters, including the time window, are applied client-side. The
+// API does not guarantee an order, so paging never stops early on
+// Since. An invalid query yields its validation error.
+func (s *AuditService) Query(
+	ctx context.Context,
+	q *AuditQuery,
+	opts ...PageOption,
+) iter.Seq2[AuditEntry, error] {
+	return func(yield func(AuditEntry, error) bool) {
+		if err := q.Validate(); err != nil {
+			yield(AuditEntry{}, err)
+
+			return
+		}
+
+		matched := 0
+
+		for entry, err := range s.All(ctx, opts...) {
+			if err != nil {
+				yield(AuditEntry{}, err)
+
+				return
+			}
+
+			if !q.Match(entry) {
+				continue
+			}
+
+			if !yield(entry, nil) {
+				return
+			}
+
+			matched++
+			if q.limit > 0 && matched >= q.limit {
+				return
+			}
+		}
+	}
+}
+
+// QueryExport streams the audit entries matching q to w in the given
+// format and returns the number of entries written.
+func (s *AuditService) QueryExport(
+	ctx context.Context,
+	q *AuditQuery,
+	w io.Writer,
+	format AuditFormat,
+) (int, error) {
+	return WriteAuditEntries(w, format, s.Query(ctx, q))
+}
+
 // Get retrieves a single audit log entry by ID.
 func (s *AuditService) Get(
 	ctx context.Context,
@@ -132,3 +212,71 @@ func (s *AuditService) Export(
 
 	return NewResponse(auditListFromGen(resp.JSON200), resp.Body), nil
 }
+
+// ExportTo streams audit entries recorded after since to w in the
+// given format, decoding the export response incrementally rather than
+// loading it into memory. Pass the zero AuditCheckpoint to export
+// everything, and the returned checkpoint to the next call to export
+// incrementally. On error no checkpoint is returned; retrying with the
+// previous checkpoint may re-export entries already written.
+func (s *AuditService) ExportTo(
+	ctx context.Context,
+	w io.Writer,
+	format AuditFormat,
+	since AuditCheckpoint,
+) (*Response[AuditExportResult], error) {
+	if format != AuditFormatJSONL && format != AuditFormatCSV {
+		return nil, fmt.Errorf("unsupported audit format %q", format)
+	}
+
+	resp, err := s.client.GetAuditExport(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("export audit logs: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp gen.ErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+
+		if err := checkError(resp.StatusCode, &errResp); err != nil {
+			return nil, err
+		}
+
+		return nil, &UnexpectedStatusError{APIError{
+			StatusCode: resp.StatusCode,
+			Message:    "unexpected status",
+		}}
+	}
+
+	checkpoint := since
+	entries := func(yield func(AuditEntry, error) bool) {
+		for entry, err := range decodeAuditStream(resp.Body) {
+			if err != nil {
+				yield(AuditEntry{}, err)
+
+				return
+			}
+
+			if !since.Includes(entry) {
+				continue
+			}
+
+			if !yield(entry, nil) {
+				return
+			}
+
+			checkpoint = checkpoint.Advance(entry)
+		}
+	}
+
+	n, err := WriteAuditEntries(w, format, entries)
+	if err != nil {
+		return nil, fmt.Errorf("export audit logs: %w", err)
+	}
+
+	return NewResponse(AuditExportResult{
+		Count:      n,
+		Checkpoint: checkpoint,
+	}, nil), nil
+}
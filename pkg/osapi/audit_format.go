@@ -0,0 +1,263 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi/gen"
+)
+
+// AuditFormat is an audit log export encoding.
+type AuditFormat string
+
+// Supported audit export formats.
+const (
+	// AuditFormatJSONL writes one JSON object per line.
+	AuditFormatJSONL AuditFormat = "jsonl"
+	// AuditFormatCSV writes a header row followed by one row per entry.
+	AuditFormatCSV AuditFormat = "csv"
+)
+
+// auditCSVHeader is the column order used for CSV exports.
+var auditCSVHeader = []string{
+	"id",
+	"timestamp",
+	"user",
+	"roles",
+	"method",
+	"path",
+	"response_code",
+	"duration_ms",
+	"source_ip",
+	"operation_id",
+}
+
+// auditRecord is the wire representation of an exported audit entry.
+// Field names match the audit API.
+type auditRecord struct {
+	ID           string    `json:"id"`
+	Timestamp    time.Time `json:"timestamp"`
+	User         string    `json:"user"`
+	Roles        []string  `json:"roles"`
+	Method       string    `json:"method"`
+	Path         string    `json:"path"`
+	ResponseCode int       `json:"response_code"`
+	DurationMs   int64     `json:"duration_ms"`
+	SourceIP     string    `json:"source_ip"`
+	OperationID  string    `json:"operation_id,omitempty"`
+}
+
+// WriteAuditEntries streams entries to w in the given format and
+// returns the number of entries written. Writing stops at the first
+// error from the sequence or the writer.
+func WriteAuditEntries(
+	w io.Writer,
+	format AuditFormat,
+	entries iter.Seq2[AuditEntry, error],
+) (int, error) {
+	switch format {
+	case AuditFormatJSONL:
+		return writeAuditJSONL(w, entries)
+	case AuditFormatCSV:
+		return writeAuditCSV(w, entries)
+	default:
+		return 0, fmt.Errorf("unsupported audit format %q", format)
+	}
+}
+
+// writeAuditJSONL writes entries as newline-delimited JSON.
+func writeAuditJSONL(
+	w io.Writer,
+	entries iter.Seq2[AuditEntry, error],
+) (int, error) {
+	enc := json.NewEncoder(w)
+	n := 0
+
+	for e, err := range entries {
+		if err != nil {
+			return n, err
+		}
+
+		if err := enc.Encode(auditRecord(e)); err != nil {
+			return n, fmt.Errorf("write audit entry: %w", err)
+		}
+
+		n++
+	}
+
+	return n, nil
+}
+
+// writeAuditCSV writes entries as CSV with a header row. Roles are
+// joined with commas within a single field.
+func writeAuditCSV(
+	w io.Writer,
+	entries iter.Seq2[AuditEntry, error],
+) (int, error) {
+	cw := csv.NewWriter(w)
+	n := 0
+
+	// The header is written with the first entry, so a stream that
+	// fails immediately leaves no output behind.
+	for e, err := range entries {
+		if err != nil {
+			cw.Flush()
+
+			return n, err
+		}
+
+		if n == 0 {
+			if err := cw.Write(auditCSVHeader); err != nil {
+				return 0, fmt.Errorf("write audit header: %w", err)
+			}
+		}
+
+		row := []string{
+			e.ID,
+			e.Timestamp.Format(time.RFC3339Nano),
+			e.User,
+			strings.Join(e.Roles, ","),
+			e.Method,
+			e.Path,
+			strconv.Itoa(e.ResponseCode),
+			strconv.FormatInt(e.DurationMs, 10),
+			e.SourceIP,
+			e.OperationID,
+		}
+
+		if err := cw.Write(row); err != nil {
+			return n, fmt.Errorf("write audit entry: %w", err)
+		}
+
+		n++
+	}
+
+	if n == 0 {
+		if err := cw.Write(auditCSVHeader); err != nil {
+			return 0, fmt.Errorf("write audit header: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return n, fmt.Errorf("write audit entries: %w", err)
+	}
+
+	return n, nil
+}
+
+// decodeAuditStream decodes a ListAuditResponse body one entry at a
+// time so large exports are never held in memory.
+func decodeAuditStream(
+	r io.Reader,
+) iter.Seq2[AuditEntry, error] {
+	return func(yield func(AuditEntry, error) bool) {
+		dec := json.NewDecoder(r)
+
+		if err := expectDelim(dec, '{'); err != nil {
+			yield(AuditEntry{}, err)
+
+			return
+		}
+
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				yield(AuditEntry{}, fmt.Errorf("decode audit export: %w", err))
+
+				return
+			}
+
+			if key != "items" {
+				var skip json.RawMessage
+				if err := dec.Decode(&skip); err != nil {
+					yield(AuditEntry{}, fmt.Errorf("decode audit export: %w", err))
+
+					return
+				}
+
+				continue
+			}
+
+			tok, err := dec.Token()
+			if err != nil {
+				yield(AuditEntry{}, fmt.Errorf("decode audit export: %w", err))
+
+				return
+			}
+
+			if tok == nil {
+				continue
+			}
+
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				yield(AuditEntry{}, fmt.Errorf("decode audit export: items is not an array"))
+
+				return
+			}
+
+			for dec.More() {
+				var g gen.AuditEntry
+				if err := dec.Decode(&g); err != nil {
+					yield(AuditEntry{}, fmt.Errorf("decode audit entry: %w", err))
+
+					return
+				}
+
+				if !yield(auditEntryFromGen(g), nil) {
+					return
+				}
+			}
+
+			if err := expectDelim(dec, ']'); err != nil {
+				yield(AuditEntry{}, err)
+
+				return
+			}
+		}
+	}
+}
+
+// expectDelim reads the next token and checks it is the delimiter d.
+func expectDelim(
+	dec *json.Decoder,
+	d json.Delim,
+) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decode audit export: %w", err)
+	}
+
+	if got, ok := tok.(json.Delim); !ok || got != d {
+		return fmt.Errorf("decode audit export: expected %q, got %v", d, tok)
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type AuditFormatPublicTestSuite struct {
+	suite.Suite
+}
+
+// entrySeq returns a sequence yielding entries followed by err when
+// err is non-nil.
+func entrySeq(
+	entries []osapi.AuditEntry,
+	err error,
+) iter.Seq2[osapi.AuditEntry, error] {
+	return func(yield func(osapi.AuditEntry, error) bool) {
+		for _, e := range entries {
+			if !yield(e, nil) {
+				return
+			}
+		}
+
+		if err != nil {
+			yield(osapi.AuditEntry{}, err)
+		}
+	}
+}
+
+func (suite *AuditFormatPublicTestSuite) TestWriteAuditEntries() {
+	entries := []osapi.AuditEntry{
+		{
+			ID:           "550e8400-e29b-41d4-a716-446655440000",
+			Timestamp:    time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+			User:         "ops@example.com",
+			Roles:        []string{"admin", "write"},
+			Method:       "POST",
+			Path:         "/node/web-01/command/exec",
+			ResponseCode: 202,
+			DurationMs:   42,
+			SourceIP:     "10.1.2.3",
+			OperationID:  "PostNodeCommandExec",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		format       osapi.AuditFormat
+		entries      iter.Seq2[osapi.AuditEntry, error]
+		writer       io.Writer
+		validateFunc func(string, int, error)
+	}{
+		{
+			name:    "when format is jsonl writes one object per line",
+			format:  osapi.AuditFormatJSONL,
+			entries: entrySeq(entries, nil),
+			validateFunc: func(out string, n int, err error) {
+				suite.NoError(err)
+				suite.Equal(1, n)
+				suite.JSONEq(`{
+					"id":"550e8400-e29b-41d4-a716-446655440000",
+					"timestamp":"2026-03-01T12:00:00Z",
+					"user":"ops@example.com",
+					"roles":["admin","write"],
+					"method":"POST",
+					"path":"/node/web-01/command/exec",
+					"response_code":202,
+					"duration_ms":42,
+					"source_ip":"10.1.2.3",
+					"operation_id":"PostNodeCommandExec"
+				}`, out)
+			},
+		},
+		{
+			name:    "when format is csv writes header and rows",
+			format:  osapi.AuditFormatCSV,
+			entries: entrySeq(entries, nil),
+			validateFunc: func(out string, n int, err error) {
+				suite.NoError(err)
+				suite.Equal(1, n)
+				suite.Equal(
+					"id,timestamp,user,roles,method,path,response_code,duration_ms,source_ip,operation_id\n"+
+						"550e8400-e29b-41d4-a716-446655440000,2026-03-01T12:00:00Z,ops@example.com,"+
+						"\"admin,write\",POST,/node/web-01/command/exec,202,42,10.1.2.3,PostNodeCommandExec\n",
+					out,
+				)
+			},
+		},
+		{
+			name:    "when jsonl sequence errors returns count and error",
+			format:  osapi.AuditFormatJSONL,
+			entries: entrySeq(entries, errors.New("page failed")),
+			validateFunc: func(_ string, n int, err error) {
+				suite.EqualError(err, "page failed")
+				suite.Equal(1, n)
+			},
+		},
+		{
+			name:    "when csv sequence errors returns count and error",
+			format:  osapi.AuditFormatCSV,
+			entries: entrySeq(entries, errors.New("page failed")),
+			validateFunc: func(out string, n int, err error) {
+				suite.EqualError(err, "page failed")
+				suite.Equal(1, n)
+				suite.Contains(out, "PostNodeCommandExec")
+			},
+		},
+		{
+			name:    "when csv sequence fails first writes nothing",
+			format:  osapi.AuditFormatCSV,
+			entries: entrySeq(nil, errors.New("page failed")),
+			validateFunc: func(out string, n int, err error) {
+				suite.EqualError(err, "page failed")
+				suite.Zero(n)
+				suite.Empty(out)
+			},
+		},
+		{
+			name:    "when csv sequence is empty writes only the header",
+			format:  osapi.AuditFormatCSV,
+			entries: entrySeq(nil, nil),
+			validateFunc: func(out string, n int, err error) {
+				suite.NoError(err)
+				suite.Zero(n)
+				suite.Equal(
+					"id,timestamp,user,roles,method,path,response_code,duration_ms,source_ip,operation_id\n",
+					out,
+				)
+			},
+		},
+		{
+			name:    "when jsonl writer fails returns error",
+			format:  osapi.AuditFormatJSONL,
+			entries: entrySeq(entries, nil),
+			writer:  &failingWriter{},
+			validateFunc: func(_ string, _ int, err error) {
+				suite.ErrorContains(err, "disk full")
+			},
+		},
+		{
+			name:    "when csv writer fails returns error",
+			format:  osapi.AuditFormatCSV,
+			entries: entrySeq(entries, nil),
+			writer:  &failingWriter{},
+			validateFunc: func(_ string, _ int, err error) {
+				suite.ErrorContains(err, "disk full")
+			},
+		},
+		{
+			name:    "when format is unsupported returns error",
+			format:  osapi.AuditFormat("xml"),
+			entries: entrySeq(entries, nil),
+			validateFunc: func(_ string, n int, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "unsupported audit format")
+				suite.Zero(n)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var buf bytes.Buffer
+
+			w := tc.writer
+			if w == nil {
+				w = &buf
+			}
+
+			n, err := osapi.WriteAuditEntries(w, tc.format, tc.entries)
+			tc.validateFunc(buf.String(), n, err)
+		})
+	}
+}
+
+// failingWriter is an io.Writer whose writes always fail.
+type failingWriter struct{}
+
+func (f *failingWriter) Write(
+	_ []byte,
+) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestAuditFormatPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditFormatPublicTestSuite))
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditFormatTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AuditFormatTestSuite) TestDecodeAuditStream() {
+	entry := `{"id":"550e8400-e29b-41d4-a716-446655440000",` +
+		`"timestamp":"2026-03-01T12:00:00Z","user":"ops","roles":["admin"],` +
+		`"method":"GET","path":"/node","source_ip":"10.0.0.1",` +
+		`"response_code":200,"duration_ms":1}`
+
+	tests := []struct {
+		name         string
+		input        string
+		stopAfter    int
+		validateFunc func(users []string, err error)
+	}{
+		{
+			name:  "when body has items decodes each entry",
+			input: `{"total_items":2,"items":[` + entry + `,` + entry + `]}`,
+			validateFunc: func(users []string, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{"ops", "ops"}, users)
+			},
+		},
+		{
+			name:  "when items is null yields nothing",
+			input: `{"items":null,"total_items":0}`,
+			validateFunc: func(users []string, err error) {
+				suite.NoError(err)
+				suite.Empty(users)
+			},
+		},
+		{
+			name:      "when consumer stops early stops decoding",
+			input:     `{"items":[` + entry + `,` + entry + `]}`,
+			stopAfter: 1,
+			validateFunc: func(users []string, err error) {
+				suite.NoError(err)
+				suite.Len(users, 1)
+			},
+		},
+		{
+			name:  "when body is not an object returns error",
+			input: `[]`,
+			validateFunc: func(_ []string, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "decode audit export")
+			},
+		},
+		{
+			name:  "when body is empty returns error",
+			input: ``,
+			validateFunc: func(_ []string, err error) {
+				suite.Error(err)
+			},
+		},
+		{
+			name:  "when items is not an array returns error",
+			input: `{"items":{}}`,
+			validateFunc: func(_ []string, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "items is not an array")
+			},
+		},
+		{
+			name:  "when entry is malformed returns error",
+			input: `{"items":[{"id":"not-a-uuid"}]}`,
+			validateFunc: func(_ []string, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "decode audit entry")
+			},
+		},
+		{
+			name:  "when other field is malformed returns error",
+			input: `{"total_items":tru}`,
+			validateFunc: func(_ []string, err error) {
+				suite.Error(err)
+			},
+		},
+		{
+			name:  "when key is truncated returns error",
+			input: `{"items":[` + entry,
+			validateFunc: func(_ []string, err error) {
+				suite.Error(err)
+			},
+		},
+		{
+			name:  "when body is truncated after key returns error",
+			input: `{"items"`,
+			validateFunc: func(_ []string, err error) {
+				suite.Error(err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var (
+				users   []string
+				iterErr error
+			)
+
+			for e, err := range decodeAuditStream(strings.NewReader(tc.input)) {
+				if err != nil {
+					iterErr = err
+
+					break
+				}
+
+				users = append(users, e.User)
+				if tc.stopAfter > 0 && len(users) == tc.stopAfter {
+					break
+				}
+			}
+
+			tc.validateFunc(users, iterErr)
+		})
+	}
+}
+
+func TestAuditFormatTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditFormatTestSuite))
+}
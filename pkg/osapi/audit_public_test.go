@@ -22,11 +22,17 @@ package osapi_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -336,6 +342,552 @@ func (suite *AuditPublicTestSuite) TestExport() {
 	}
 }
 
+func (suite *AuditPublicTestSuite) TestAll() {
+	const total = 5
+
+	pagedAudit := func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		items := []map[string]any{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, map[string]any{
+				"id":            fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+				"timestamp":     "2026-01-01T00:00:00Z",
+				"user":          fmt.Sprintf("user-%d", i),
+				"roles":         []string{"admin"},
+				"method":        "GET",
+				"path":          "/node/web-01",
+				"response_code": 200,
+				"duration_ms":   1,
+				"source_ip":     "10.0.0.1",
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items":       items,
+			"total_items": total,
+		})
+	}
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		opts         []osapi.PageOption
+		validateFunc func(users []string, err error)
+	}{
+		{
+			name:    "when entries span multiple pages yields all of them",
+			handler: pagedAudit,
+			opts:    []osapi.PageOption{osapi.WithPageSize(2)},
+			validateFunc: func(users []string, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{
+					"user-0",
+					"user-1",
+					"user-2",
+					"user-3",
+					"user-4",
+				}, users)
+			},
+		},
+		{
+			name:    "when prefetching yields all entries",
+			handler: pagedAudit,
+			opts: []osapi.PageOption{
+				osapi.WithPageSize(2),
+				osapi.WithPrefetch(),
+			},
+			validateFunc: func(users []string, err error) {
+				suite.NoError(err)
+				suite.Len(users, total)
+			},
+		},
+		{
+			name: "when server returns error yields it",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":"forbidden"}`))
+			},
+			validateFunc: func(users []string, err error) {
+				suite.Empty(users)
+
+				var target *osapi.AuthError
+				suite.True(errors.As(err, &target))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			var (
+				users   []string
+				iterErr error
+			)
+
+			for entry, err := range sut.Audit.All(suite.ctx, tc.opts...) {
+				if err != nil {
+					iterErr = err
+
+					break
+				}
+
+				users = append(users, entry.User)
+			}
+
+			tc.validateFunc(users, iterErr)
+		})
+	}
+}
+
+// newestFirstAudit serves count entries one minute apart, newest
+// first starting at base, honoring limit/offset and counting requests.
+func newestFirstAudit(
+	base time.Time,
+	count int,
+	requests *atomic.Int32,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		items := []map[string]any{}
+		for i := offset; i < count && i < offset+limit; i++ {
+			method := "GET"
+			if i%2 == 0 {
+				method = "POST"
+			}
+
+			items = append(items, map[string]any{
+				"id":            fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+				"timestamp":     base.Add(-time.Duration(i) * time.Minute),
+				"user":          fmt.Sprintf("user-%d", i),
+				"roles":         []string{"admin"},
+				"method":        method,
+				"path":          "/node/web-01/command/exec",
+				"response_code": 202,
+				"duration_ms":   1,
+				"source_ip":     "10.0.0.1",
+				"operation_id":  "PostNodeCommandExec",
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items":       items,
+			"total_items": count,
+		})
+	}
+}
+
+func (suite *AuditPublicTestSuite) TestQuery() {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		handler      func(*atomic.Int32) http.HandlerFunc
+		query        *osapi.AuditQuery
+		validateFunc func(users []string, requests int32, err error)
+	}{
+		{
+			name: "when filtering by method returns matching entries",
+			handler: func(r *atomic.Int32) http.HandlerFunc {
+				return newestFirstAudit(base, 6, r)
+			},
+			query: osapi.NewAuditQuery().Method("POST"),
+			validateFunc: func(users []string, _ int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{"user-0", "user-2", "user-4"}, users)
+			},
+		},
+		{
+			name: "when since is set filters every page",
+			handler: func(r *atomic.Int32) http.HandlerFunc {
+				return newestFirstAudit(base, 10, r)
+			},
+			query: osapi.NewAuditQuery().
+				Since(base.Add(-3 * time.Minute)).
+				Until(base),
+			validateFunc: func(users []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{"user-1", "user-2", "user-3"}, users)
+				suite.Equal(int32(5), requests)
+			},
+		},
+		{
+			name: "when entries are out of order keeps every match",
+			handler: func(r *atomic.Int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, req *http.Request) {
+					r.Add(1)
+
+					offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+					ages := []int{1, 10, 2, 20, 3}
+
+					items := []map[string]any{}
+					for i := offset; i < len(ages) && i < offset+2; i++ {
+						items = append(items, map[string]any{
+							"id":            fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+							"timestamp":     base.Add(-time.Duration(ages[i]) * time.Minute),
+							"user":          fmt.Sprintf("user-%d", ages[i]),
+							"roles":         []string{"admin"},
+							"method":        "GET",
+							"path":          "/node/web-01",
+							"response_code": 200,
+							"duration_ms":   1,
+							"source_ip":     "10.0.0.1",
+							"operation_id":  "GetNodeStatus",
+						})
+					}
+
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"items":       items,
+						"total_items": len(ages),
+					})
+				}
+			},
+			query: osapi.NewAuditQuery().Since(base.Add(-5 * time.Minute)),
+			validateFunc: func(users []string, _ int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{"user-1", "user-2", "user-3"}, users)
+			},
+		},
+		{
+			name: "when limit is set stops after limit matches",
+			handler: func(r *atomic.Int32) http.HandlerFunc {
+				return newestFirstAudit(base, 100, r)
+			},
+			query: osapi.NewAuditQuery().Limit(3),
+			validateFunc: func(users []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Len(users, 3)
+				suite.Equal(int32(2), requests)
+			},
+		},
+		{
+			name: "when query is invalid yields validation error",
+			handler: func(r *atomic.Int32) http.HandlerFunc {
+				return newestFirstAudit(base, 1, r)
+			},
+			query: osapi.NewAuditQuery().PathGlob("["),
+			validateFunc: func(users []string, requests int32, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid path glob")
+				suite.Empty(users)
+				suite.Zero(requests)
+			},
+		},
+		{
+			name: "when server returns error yields it",
+			handler: func(_ *atomic.Int32) http.HandlerFunc {
+				return func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+					_, _ = w.Write([]byte(`{"error":"boom"}`))
+				}
+			},
+			query: osapi.NewAuditQuery(),
+			validateFunc: func(users []string, _ int32, err error) {
+				suite.Empty(users)
+
+				var target *osapi.ServerError
+				suite.True(errors.As(err, &target))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var requests atomic.Int32
+
+			server := httptest.NewServer(tc.handler(&requests))
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			var (
+				users   []string
+				iterErr error
+			)
+
+			for entry, err := range sut.Audit.Query(
+				suite.ctx,
+				tc.query,
+				osapi.WithPageSize(2),
+			) {
+				if err != nil {
+					iterErr = err
+
+					break
+				}
+
+				users = append(users, entry.User)
+			}
+
+			tc.validateFunc(users, requests.Load(), iterErr)
+		})
+	}
+}
+
+func (suite *AuditPublicTestSuite) TestQueryExport() {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		format       osapi.AuditFormat
+		validateFunc func(out string, n int, err error)
+	}{
+		{
+			name:   "when exporting jsonl writes matching entries",
+			format: osapi.AuditFormatJSONL,
+			validateFunc: func(out string, n int, err error) {
+				suite.NoError(err)
+				suite.Equal(2, n)
+				suite.Equal(2, strings.Count(out, "\n"))
+				suite.Contains(out, `"user":"user-1"`)
+			},
+		},
+		{
+			name:   "when exporting csv writes header and matching rows",
+			format: osapi.AuditFormatCSV,
+			validateFunc: func(out string, n int, err error) {
+				suite.NoError(err)
+				suite.Equal(2, n)
+				suite.Equal(3, strings.Count(out, "\n"))
+				suite.True(strings.HasPrefix(out, "id,timestamp,user"))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var requests atomic.Int32
+
+			server := httptest.NewServer(newestFirstAudit(base, 4, &requests))
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			var buf strings.Builder
+
+			n, err := sut.Audit.QueryExport(
+				suite.ctx,
+				osapi.NewAuditQuery().Method("GET"),
+				&buf,
+				tc.format,
+			)
+			tc.validateFunc(buf.String(), n, err)
+		})
+	}
+}
+
+func (suite *AuditPublicTestSuite) TestExportTo() {
+	const exportBody = `{"total_items":3,"items":[
+		{"id":"00000000-0000-0000-0000-000000000003","timestamp":"2026-03-01T12:02:00Z",
+		 "user":"carol","roles":["admin"],"method":"GET","path":"/node",
+		 "source_ip":"10.0.0.1","response_code":200,"duration_ms":1},
+		{"id":"00000000-0000-0000-0000-000000000002","timestamp":"2026-03-01T12:01:00Z",
+		 "user":"bob","roles":["admin"],"method":"GET","path":"/node",
+		 "source_ip":"10.0.0.1","response_code":200,"duration_ms":1},
+		{"id":"00000000-0000-0000-0000-000000000001","timestamp":"2026-03-01T12:00:00Z",
+		 "user":"alice","roles":["admin"],"method":"GET","path":"/node",
+		 "source_ip":"10.0.0.1","response_code":200,"duration_ms":1}
+	]}`
+
+	exportHandler := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(exportBody))
+	}
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		serverURL    string
+		format       osapi.AuditFormat
+		since        osapi.AuditCheckpoint
+		validateFunc func(string, *osapi.Response[osapi.AuditExportResult], error)
+	}{
+		{
+			name:    "when since is zero exports every entry",
+			handler: exportHandler,
+			format:  osapi.AuditFormatJSONL,
+			validateFunc: func(
+				out string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.Equal(3, resp.Data.Count)
+				suite.Equal(3, strings.Count(out, "\n"))
+				suite.Equal(
+					time.Date(2026, 3, 1, 12, 2, 0, 0, time.UTC),
+					resp.Data.Checkpoint.Timestamp.UTC(),
+				)
+				suite.Equal(
+					[]string{"00000000-0000-0000-0000-000000000003"},
+					resp.Data.Checkpoint.IDs,
+				)
+			},
+		},
+		{
+			name:    "when checkpoint is set exports only newer entries",
+			handler: exportHandler,
+			format:  osapi.AuditFormatCSV,
+			since: osapi.AuditCheckpoint{
+				Timestamp: time.Date(2026, 3, 1, 12, 1, 0, 0, time.UTC),
+				IDs:       []string{"00000000-0000-0000-0000-000000000002"},
+			},
+			validateFunc: func(
+				out string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.Equal(1, resp.Data.Count)
+				suite.Contains(out, "carol")
+				suite.NotContains(out, "bob")
+			},
+		},
+		{
+			name:    "when nothing is new returns the same checkpoint",
+			handler: exportHandler,
+			format:  osapi.AuditFormatJSONL,
+			since: osapi.AuditCheckpoint{
+				Timestamp: time.Date(2026, 3, 1, 12, 5, 0, 0, time.UTC),
+			},
+			validateFunc: func(
+				out string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.Zero(resp.Data.Count)
+				suite.Empty(out)
+				suite.Equal(
+					time.Date(2026, 3, 1, 12, 5, 0, 0, time.UTC),
+					resp.Data.Checkpoint.Timestamp,
+				)
+			},
+		},
+		{
+			name:    "when format is unsupported returns error",
+			handler: exportHandler,
+			format:  osapi.AuditFormat("xml"),
+			validateFunc: func(
+				_ string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.Error(err)
+				suite.Nil(resp)
+				suite.Contains(err.Error(), "unsupported audit format")
+			},
+		},
+		{
+			name: "when server returns 403 returns AuthError",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":"forbidden"}`))
+			},
+			format: osapi.AuditFormatJSONL,
+			validateFunc: func(
+				_ string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.Nil(resp)
+
+				var target *osapi.AuthError
+				suite.True(errors.As(err, &target))
+				suite.Equal("forbidden", target.Message)
+			},
+		},
+		{
+			name: "when server returns unexpected success status returns UnexpectedStatusError",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			format: osapi.AuditFormatJSONL,
+			validateFunc: func(
+				_ string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.Nil(resp)
+
+				var target *osapi.UnexpectedStatusError
+				suite.True(errors.As(err, &target))
+			},
+		},
+		{
+			name: "when body is malformed returns error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"items":[{`))
+			},
+			format: osapi.AuditFormatJSONL,
+			validateFunc: func(
+				_ string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.Nil(resp)
+				suite.Error(err)
+				suite.Contains(err.Error(), "export audit logs:")
+			},
+		},
+		{
+			name:      "when client HTTP request fails returns error",
+			serverURL: "http://127.0.0.1:0",
+			format:    osapi.AuditFormatJSONL,
+			validateFunc: func(
+				_ string,
+				resp *osapi.Response[osapi.AuditExportResult],
+				err error,
+			) {
+				suite.Nil(resp)
+				suite.Error(err)
+				suite.Contains(err.Error(), "export audit logs:")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			serverURL := tc.serverURL
+			if serverURL == "" {
+				server := httptest.NewServer(tc.handler)
+				defer server.Close()
+
+				serverURL = server.URL
+			}
+
+			sut := osapi.New(serverURL, "test-token")
+
+			var buf strings.Builder
+
+			resp, err := sut.Audit.ExportTo(suite.ctx, &buf, tc.format, tc.since)
+			tc.validateFunc(buf.String(), resp, err)
+		})
+	}
+}
+
 func TestAuditPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(AuditPublicTestSuite))
 }
@@ -0,0 +1,268 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"fmt"
+	"net/netip"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AuditQuery selects audit log entries. Create one with NewAuditQuery
+// and chain filter methods; an entry must match every filter that is
+// set. Filters accepting several values match any of them.
+//
+// The audit API only supports limit/offset pagination and does not
+// guarantee an order, so AuditService.Query pages through the whole log
+// and applies every filter client-side.
+type AuditQuery struct {
+	since        time.Time
+	until        time.Time
+	users        []string
+	roles        []string
+	methods      []string
+	pathGlob     string
+	codeClasses  []int
+	operationIDs []string
+	sourceIPs    []string
+	limit        int
+}
+
+// NewAuditQuery returns an empty query that matches every entry.
+func NewAuditQuery() *AuditQuery {
+	return &AuditQuery{}
+}
+
+// Since matches entries recorded at or after t.
+func (q *AuditQuery) Since(
+	t time.Time,
+) *AuditQuery {
+	q.since = t
+
+	return q
+}
+
+// Until matches entries recorded before t.
+func (q *AuditQuery) Until(
+	t time.Time,
+) *AuditQuery {
+	q.until = t
+
+	return q
+}
+
+// User matches entries made by any of the given users.
+func (q *AuditQuery) User(
+	users ...string,
+) *AuditQuery {
+	q.users = append(q.users, users...)
+
+	return q
+}
+
+// Role matches entries whose roles include any of the given roles.
+func (q *AuditQuery) Role(
+	roles ...string,
+) *AuditQuery {
+	q.roles = append(q.roles, roles...)
+
+	return q
+}
+
+// Method matches entries with any of the given HTTP methods,
+// case-insensitively.
+func (q *AuditQuery) Method(
+	methods ...string,
+) *AuditQuery {
+	for _, m := range methods {
+		q.methods = append(q.methods, strings.ToUpper(m))
+	}
+
+	return q
+}
+
+// PathGlob matches entries whose request path matches pattern, using
+// path.Match syntax (e.g., "/node/*/command/*"). A "*" does not cross
+// "/" boundaries.
+func (q *AuditQuery) PathGlob(
+	pattern string,
+) *AuditQuery {
+	q.pathGlob = pattern
+
+	return q
+}
+
+// ResponseCodeClass matches entries whose response code falls in any
+// of the given classes, where a class is the hundreds digit (e.g., 4
+// for 4xx).
+func (q *AuditQuery) ResponseCodeClass(
+	classes ...int,
+) *AuditQuery {
+	q.codeClasses = append(q.codeClasses, classes...)
+
+	return q
+}
+
+// OperationID matches entries for any of the given OpenAPI operation
+// IDs (e.g., "PostNodeCommandExec").
+func (q *AuditQuery) OperationID(
+	ids ...string,
+) *AuditQuery {
+	q.operationIDs = append(q.operationIDs, ids...)
+
+	return q
+}
+
+// SourceIP matches entries from any of the given addresses. Each value
+// is an exact IP address or a CIDR prefix (e.g., "10.0.0.0/8").
+func (q *AuditQuery) SourceIP(
+	ips ...string,
+) *AuditQuery {
+	q.sourceIPs = append(q.sourceIPs, ips...)
+
+	return q
+}
+
+// Limit caps the number of matching entries returned. Zero means no
+// limit.
+func (q *AuditQuery) Limit(
+	n int,
+) *AuditQuery {
+	q.limit = n
+
+	return q
+}
+
+// Validate checks the path glob, source IP filters, and time range.
+func (q *AuditQuery) Validate() error {
+	if q.pathGlob != "" {
+		if _, err := path.Match(q.pathGlob, ""); err != nil {
+			return fmt.Errorf("invalid path glob %q: %w", q.pathGlob, err)
+		}
+	}
+
+	for _, ip := range q.sourceIPs {
+		if _, err := parseSourceIPFilter(ip); err != nil {
+			return err
+		}
+	}
+
+	if !q.since.IsZero() && !q.until.IsZero() && !q.since.Before(q.until) {
+		return fmt.Errorf("invalid time range: since must be before until")
+	}
+
+	return nil
+}
+
+// Match returns true if the entry satisfies every filter in the query.
+// Invalid path globs and source IP filters never match; call Validate
+// to detect them.
+func (q *AuditQuery) Match(
+	e AuditEntry,
+) bool {
+	if !q.since.IsZero() && e.Timestamp.Before(q.since) {
+		return false
+	}
+
+	if !q.until.IsZero() && !e.Timestamp.Before(q.until) {
+		return false
+	}
+
+	if len(q.users) > 0 && !slices.Contains(q.users, e.User) {
+		return false
+	}
+
+	if len(q.roles) > 0 && !slices.ContainsFunc(e.Roles, func(r string) bool {
+		return slices.Contains(q.roles, r)
+	}) {
+		return false
+	}
+
+	if len(q.methods) > 0 && !slices.Contains(q.methods, strings.ToUpper(e.Method)) {
+		return false
+	}
+
+	if q.pathGlob != "" {
+		if ok, err := path.Match(q.pathGlob, e.Path); err != nil || !ok {
+			return false
+		}
+	}
+
+	if len(q.codeClasses) > 0 && !slices.Contains(q.codeClasses, e.ResponseCode/100) {
+		return false
+	}
+
+	if len(q.operationIDs) > 0 && !slices.Contains(q.operationIDs, e.OperationID) {
+		return false
+	}
+
+	if len(q.sourceIPs) > 0 && !q.matchSourceIP(e.SourceIP) {
+		return false
+	}
+
+	return true
+}
+
+// matchSourceIP returns true if addr matches any source IP filter.
+func (q *AuditQuery) matchSourceIP(
+	addr string,
+) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return slices.Contains(q.sourceIPs, addr)
+	}
+
+	for _, f := range q.sourceIPs {
+		prefix, err := parseSourceIPFilter(f)
+		if err == nil && prefix.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSourceIPFilter parses an IP address or CIDR prefix. A bare
+// address is treated as a single-host prefix.
+func parseSourceIPFilter(
+	s string,
+) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid source IP filter %q: %w", s, err)
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid source IP filter %q: %w", s, err)
+	}
+
+	addr = addr.Unmap()
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
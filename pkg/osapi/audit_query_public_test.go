@@ -0,0 +1,219 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type AuditQueryPublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AuditQueryPublicTestSuite) TestMatch() {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	entry := osapi.AuditEntry{
+		ID:           "550e8400-e29b-41d4-a716-446655440000",
+		Timestamp:    now,
+		User:         "ops@example.com",
+		Roles:        []string{"admin", "write"},
+		Method:       "POST",
+		Path:         "/node/web-01/command/exec",
+		ResponseCode: 202,
+		SourceIP:     "10.1.2.3",
+		OperationID:  "PostNodeCommandExec",
+	}
+
+	tests := []struct {
+		name  string
+		query *osapi.AuditQuery
+		// sourceIP overrides the entry's source IP when set.
+		sourceIP string
+		want     bool
+	}{
+		{
+			name:  "when query is empty matches",
+			query: osapi.NewAuditQuery(),
+			want:  true,
+		},
+		{
+			name: "when all filters match",
+			query: osapi.NewAuditQuery().
+				Since(now.Add(-time.Hour)).
+				Until(now.Add(time.Hour)).
+				User("ops@example.com").
+				Role("admin").
+				Method("post").
+				PathGlob("/node/*/command/*").
+				ResponseCodeClass(2).
+				OperationID("PostNodeCommandExec").
+				SourceIP("10.0.0.0/8"),
+			want: true,
+		},
+		{
+			name:  "when entry is before since does not match",
+			query: osapi.NewAuditQuery().Since(now.Add(time.Minute)),
+			want:  false,
+		},
+		{
+			name:  "when entry is at until does not match",
+			query: osapi.NewAuditQuery().Until(now),
+			want:  false,
+		},
+		{
+			name:  "when user differs does not match",
+			query: osapi.NewAuditQuery().User("alice", "bob"),
+			want:  false,
+		},
+		{
+			name:  "when no role overlaps does not match",
+			query: osapi.NewAuditQuery().Role("read"),
+			want:  false,
+		},
+		{
+			name:  "when method differs does not match",
+			query: osapi.NewAuditQuery().Method("GET", "DELETE"),
+			want:  false,
+		},
+		{
+			name:  "when path glob does not match",
+			query: osapi.NewAuditQuery().PathGlob("/node/db-*/command/*"),
+			want:  false,
+		},
+		{
+			name:  "when path glob is invalid does not match",
+			query: osapi.NewAuditQuery().PathGlob("/node/["),
+			want:  false,
+		},
+		{
+			name:  "when response class differs does not match",
+			query: osapi.NewAuditQuery().ResponseCodeClass(4, 5),
+			want:  false,
+		},
+		{
+			name:  "when operation ID differs does not match",
+			query: osapi.NewAuditQuery().OperationID("GetNodeHostname"),
+			want:  false,
+		},
+		{
+			name:  "when source IP matches exactly",
+			query: osapi.NewAuditQuery().SourceIP("192.168.1.1", "10.1.2.3"),
+			want:  true,
+		},
+		{
+			name:  "when source IP is outside prefix does not match",
+			query: osapi.NewAuditQuery().SourceIP("192.168.0.0/16"),
+			want:  false,
+		},
+		{
+			name:     "when source IP is unparsable matches it exactly",
+			query:    osapi.NewAuditQuery().SourceIP("unix-socket"),
+			sourceIP: "unix-socket",
+			want:     true,
+		},
+		{
+			name:     "when source IP is unparsable does not match other addresses",
+			query:    osapi.NewAuditQuery().SourceIP("10.0.0.1"),
+			sourceIP: "unix-socket",
+			want:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			e := entry
+			if tc.sourceIP != "" {
+				e.SourceIP = tc.sourceIP
+			}
+
+			suite.Equal(tc.want, tc.query.Match(e))
+		})
+	}
+}
+
+func (suite *AuditQueryPublicTestSuite) TestValidate() {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		query        *osapi.AuditQuery
+		validateFunc func(error)
+	}{
+		{
+			name: "when query is valid returns nil",
+			query: osapi.NewAuditQuery().
+				Since(now.Add(-time.Hour)).
+				Until(now).
+				PathGlob("/node/*").
+				SourceIP("10.0.0.1", "fd00::/8"),
+			validateFunc: func(err error) {
+				suite.NoError(err)
+			},
+		},
+		{
+			name:  "when path glob is invalid returns error",
+			query: osapi.NewAuditQuery().PathGlob("/node/["),
+			validateFunc: func(err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid path glob")
+			},
+		},
+		{
+			name:  "when source IP is invalid returns error",
+			query: osapi.NewAuditQuery().SourceIP("not-an-ip"),
+			validateFunc: func(err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid source IP filter")
+			},
+		},
+		{
+			name:  "when source CIDR is invalid returns error",
+			query: osapi.NewAuditQuery().SourceIP("10.0.0.0/99"),
+			validateFunc: func(err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid source IP filter")
+			},
+		},
+		{
+			name:  "when since is not before until returns error",
+			query: osapi.NewAuditQuery().Since(now).Until(now),
+			validateFunc: func(err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid time range")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(tc.query.Validate())
+		})
+	}
+}
+
+func TestAuditQueryPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditQueryPublicTestSuite))
+}
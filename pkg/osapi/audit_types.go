@@ -21,6 +21,10 @@
 package osapi
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/osapi-io/osapi-sdk/pkg/osapi/gen"
@@ -46,6 +50,88 @@ type AuditList struct {
 	TotalItems int
 }
 
+// AuditCheckpoint marks the position reached by an incremental audit
+// export. Entries at or before Timestamp are skipped on the next
+// export, except entries at exactly Timestamp whose IDs are not in IDs.
+// The zero value exports everything.
+type AuditCheckpoint struct {
+	Timestamp time.Time `json:"ts"`
+	IDs       []string  `json:"ids,omitempty"`
+}
+
+// AuditExportResult describes a completed streaming audit export.
+type AuditExportResult struct {
+	// Count is the number of entries written.
+	Count int
+	// Checkpoint is the position to pass to the next export. It is
+	// unchanged from the input when no new entries were written.
+	Checkpoint AuditCheckpoint
+}
+
+// IsZero returns true if the checkpoint has no position.
+func (c AuditCheckpoint) IsZero() bool {
+	return c.Timestamp.IsZero()
+}
+
+// Includes returns true if the entry was recorded after the checkpoint.
+func (c AuditCheckpoint) Includes(
+	e AuditEntry,
+) bool {
+	if c.IsZero() || e.Timestamp.After(c.Timestamp) {
+		return true
+	}
+
+	return e.Timestamp.Equal(c.Timestamp) && !slices.Contains(c.IDs, e.ID)
+}
+
+// Advance returns the checkpoint moved past the entry.
+func (c AuditCheckpoint) Advance(
+	e AuditEntry,
+) AuditCheckpoint {
+	switch {
+	case c.IsZero() || e.Timestamp.After(c.Timestamp):
+		return AuditCheckpoint{Timestamp: e.Timestamp, IDs: []string{e.ID}}
+	case e.Timestamp.Equal(c.Timestamp) && !slices.Contains(c.IDs, e.ID):
+		return AuditCheckpoint{Timestamp: c.Timestamp, IDs: append(slices.Clone(c.IDs), e.ID)}
+	default:
+		return c
+	}
+}
+
+// Token encodes the checkpoint as an opaque string suitable for
+// persisting between export runs. The zero checkpoint encodes as "".
+func (c AuditCheckpoint) Token() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseAuditCheckpoint decodes a token produced by AuditCheckpoint.Token.
+// An empty token returns the zero checkpoint.
+func ParseAuditCheckpoint(
+	token string,
+) (AuditCheckpoint, error) {
+	var c AuditCheckpoint
+	if token == "" {
+		return c, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("invalid audit checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("invalid audit checkpoint: %w", err)
+	}
+
+	return c, nil
+}
+
 // auditEntryFromGen converts a gen.AuditEntry to an AuditEntry.
 func auditEntryFromGen(
 	g gen.AuditEntry,
@@ -228,6 +228,118 @@ func (suite *AuditTypesTestSuite) TestAuditListFromGen() {
 	}
 }
 
+func (suite *AuditTypesTestSuite) TestAuditCheckpoint() {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cp := AuditCheckpoint{Timestamp: t0, IDs: []string{"a"}}
+
+	tests := []struct {
+		name        string
+		checkpoint  AuditCheckpoint
+		entry       AuditEntry
+		wantInclude bool
+		wantAdvance AuditCheckpoint
+	}{
+		{
+			name:        "when checkpoint is zero includes and starts at entry",
+			entry:       AuditEntry{ID: "a", Timestamp: t0},
+			wantInclude: true,
+			wantAdvance: AuditCheckpoint{Timestamp: t0, IDs: []string{"a"}},
+		},
+		{
+			name:        "when entry is newer includes and moves forward",
+			checkpoint:  cp,
+			entry:       AuditEntry{ID: "b", Timestamp: t0.Add(time.Second)},
+			wantInclude: true,
+			wantAdvance: AuditCheckpoint{
+				Timestamp: t0.Add(time.Second),
+				IDs:       []string{"b"},
+			},
+		},
+		{
+			name:        "when entry shares timestamp with new ID includes and records ID",
+			checkpoint:  cp,
+			entry:       AuditEntry{ID: "b", Timestamp: t0},
+			wantInclude: true,
+			wantAdvance: AuditCheckpoint{Timestamp: t0, IDs: []string{"a", "b"}},
+		},
+		{
+			name:        "when entry was already exported excludes it",
+			checkpoint:  cp,
+			entry:       AuditEntry{ID: "a", Timestamp: t0},
+			wantInclude: false,
+			wantAdvance: cp,
+		},
+		{
+			name:        "when entry is older excludes it",
+			checkpoint:  cp,
+			entry:       AuditEntry{ID: "z", Timestamp: t0.Add(-time.Second)},
+			wantInclude: false,
+			wantAdvance: cp,
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.Equal(tc.wantInclude, tc.checkpoint.Includes(tc.entry))
+			suite.Equal(tc.wantAdvance, tc.checkpoint.Advance(tc.entry))
+		})
+	}
+}
+
+func (suite *AuditTypesTestSuite) TestAuditCheckpointToken() {
+	cp := AuditCheckpoint{
+		Timestamp: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		IDs:       []string{"a", "b"},
+	}
+
+	tests := []struct {
+		name         string
+		token        string
+		validateFunc func(AuditCheckpoint, error)
+	}{
+		{
+			name:  "when token round-trips returns checkpoint",
+			token: cp.Token(),
+			validateFunc: func(got AuditCheckpoint, err error) {
+				suite.NoError(err)
+				suite.True(cp.Timestamp.Equal(got.Timestamp))
+				suite.Equal(cp.IDs, got.IDs)
+			},
+		},
+		{
+			name:  "when token is empty returns zero checkpoint",
+			token: AuditCheckpoint{}.Token(),
+			validateFunc: func(got AuditCheckpoint, err error) {
+				suite.NoError(err)
+				suite.True(got.IsZero())
+			},
+		},
+		{
+			name:  "when token is not base64 returns error",
+			token: "!!!",
+			validateFunc: func(_ AuditCheckpoint, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid audit checkpoint")
+			},
+		},
+		{
+			name:  "when token is not JSON returns error",
+			token: "bm90LWpzb24",
+			validateFunc: func(_ AuditCheckpoint, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid audit checkpoint")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			got, err := ParseAuditCheckpoint(tc.token)
+			tc.validateFunc(got, err)
+		})
+	}
+}
+
 func TestAuditTypesTestSuite(t *testing.T) {
 	suite.Run(t, new(AuditTypesTestSuite))
 }
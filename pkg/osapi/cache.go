@@ -0,0 +1,251 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi
+
+import (
+	"container/list"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithCache caches the responses of read-only node and agent queries
+// for ttl, keeping at most maxEntries responses and evicting the least
+// recently used. Cached responses are keyed by operation and target,
+// and are shared between callers, so they must not be modified.
+// Write operations on a target invalidate its entries.
+func WithCache(
+	ttl time.Duration,
+	maxEntries int,
+) Option {
+	return func(c *Client) {
+		c.cache = &responseCache{
+			ttl:        ttl,
+			maxEntries: max(maxEntries, 1),
+			entries:    make(map[cacheKey]*list.Element),
+			order:      list.New(),
+		}
+	}
+}
+
+// CacheStats reports the activity of the response cache.
+type CacheStats struct {
+	// Hits is the number of queries answered from the cache.
+	Hits int64
+	// Misses is the number of queries sent to the API.
+	Misses int64
+	// Evictions is the number of entries dropped to stay within
+	// maxEntries.
+	Evictions int64
+	// Entries is the number of responses currently cached.
+	Entries int
+}
+
+// CacheStats returns the response cache statistics. It returns a zero
+// value when the client was created without WithCache.
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+
+	return c.cache.snapshot()
+}
+
+// InvalidateCache drops the cached responses for target, together with
+// any broadcast or label-selector entries that may include it. An empty
+// target clears the whole cache.
+func (c *Client) InvalidateCache(
+	target string,
+) {
+	c.cache.invalidate(target)
+}
+
+type cacheKey struct {
+	op     string
+	target string
+	arg    string
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	value   any
+	expires time.Time
+}
+
+type responseCache struct {
+	ttl        time.Duration
+	maxEntries int
+	logger     *slog.Logger
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	order   *list.List
+	stats   CacheStats
+}
+
+// cacheLookup returns the cached response for key, if it is present
+// and has not expired. A nil cache never hits.
+func cacheLookup[T any](
+	c *responseCache,
+	key cacheKey,
+) (*Response[T], bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	v, ok := c.get(key)
+	if !ok {
+		return nil, false
+	}
+
+	resp, ok := v.(*Response[T])
+
+	return resp, ok
+}
+
+func (c *responseCache) get(
+	key cacheKey,
+) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if ok && time.Now().After(el.Value.(*cacheEntry).expires) {
+		c.remove(el)
+		ok = false
+	}
+
+	if !ok {
+		c.stats.Misses++
+		c.log("cache miss", key)
+
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	c.stats.Hits++
+	c.log("cache hit", key)
+
+	return el.Value.(*cacheEntry).value, true
+}
+
+// store caches value under key unless err is set. A nil cache is a
+// no-op.
+func (c *responseCache) store(
+	key cacheKey,
+	value any,
+	err error,
+) {
+	if c == nil || err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{
+		key:     key,
+		value:   value,
+		expires: time.Now().Add(c.ttl),
+	}
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// invalidate drops the entries that a write to target may have made
+// stale: entries for target itself and every entry addressed to more
+// than one host. Writes to "_any", "_all", or a label selector, or an
+// empty target, clear the whole cache. A nil cache is a no-op.
+func (c *responseCache) invalidate(
+	target string,
+) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	single := isHostTarget(target)
+	for key, el := range c.entries {
+		if !single || key.target == target || !isHostTarget(key.target) {
+			c.remove(el)
+		}
+	}
+
+	c.logger.Debug("cache invalidated",
+		slog.String("target", target),
+		slog.Int("entries", c.order.Len()),
+	)
+}
+
+// remove drops el. Callers must hold c.mu.
+func (c *responseCache) remove(
+	el *list.Element,
+) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+// log records a lookup with the running hit and miss counts. Callers
+// must hold c.mu.
+func (c *responseCache) log(
+	msg string,
+	key cacheKey,
+) {
+	c.logger.Debug(msg,
+		slog.String("op", key.op),
+		slog.String("target", key.target),
+		slog.Int64("hits", c.stats.Hits),
+		slog.Int64("misses", c.stats.Misses),
+	)
+}
+
+func (c *responseCache) snapshot() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+
+	return stats
+}
+
+// isHostTarget reports whether target addresses a single named host
+// rather than "_any", "_all", or a label selector.
+func isHostTarget(
+	target string,
+) bool {
+	return target != "" && target != "_any" && target != "_all" &&
+		!strings.Contains(target, ":")
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type CachePublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func (suite *CachePublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.hits = make(map[string]int)
+}
+
+func (suite *CachePublicTestSuite) client(
+	opts ...osapi.Option,
+) *osapi.Client {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			suite.mu.Lock()
+			suite.hits[r.Method+" "+r.URL.Path]++
+			suite.mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodPut:
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"results":[{"hostname":"web-01","status":"completed"}]}`))
+			case r.Method == http.MethodPost && r.URL.Path == "/job":
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"job_id":"550e8400-e29b-41d4-a716-446655440000","status":"created"}`))
+			case strings.HasSuffix(r.URL.Path, "/retry"):
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"job_id":"550e8400-e29b-41d4-a716-446655440001","status":"created"}`))
+			case r.URL.Path == "/agent/web-01/drain":
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"message":"drain initiated"}`))
+			case r.URL.Path == "/agent/web-01":
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"hostname":"web-01","status":"Ready"}`))
+			case r.URL.Path == "/node/_all/uptime":
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"results":[{"hostname":"web-02","error":"timeout"}]}`))
+			default:
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"results":[{"hostname":"web-01"}]}`))
+			}
+		}),
+	)
+	suite.T().Cleanup(server.Close)
+
+	opts = append([]osapi.Option{osapi.WithLogger(slog.Default())}, opts...)
+
+	return osapi.New(server.URL, "test-token", opts...)
+}
+
+func (suite *CachePublicTestSuite) requests(
+	key string,
+) int {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+
+	return suite.hits[key]
+}
+
+func (suite *CachePublicTestSuite) TestWithCache() {
+	tests := []struct {
+		name         string
+		opts         []osapi.Option
+		validateFunc func(*osapi.Client)
+	}{
+		{
+			name: "when query repeats within ttl serves from cache",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				first, err := sut.Node.OS(suite.ctx, "web-01")
+				suite.Require().NoError(err)
+
+				second, err := sut.Node.OS(suite.ctx, "web-01")
+				suite.Require().NoError(err)
+
+				suite.Same(first, second)
+				suite.Equal(1, suite.requests("GET /node/web-01/os"))
+				suite.Equal(osapi.CacheStats{Hits: 1, Misses: 1, Entries: 1}, sut.CacheStats())
+			},
+		},
+		{
+			name: "when targets differ caches separately",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.Status(suite.ctx, "web-01")
+				_, _ = sut.Node.Status(suite.ctx, "web-02")
+				_, _ = sut.Node.GetDNS(suite.ctx, "web-01", "eth0")
+				_, _ = sut.Node.GetDNS(suite.ctx, "web-01", "eth1")
+
+				suite.Equal(4, sut.CacheStats().Entries)
+				suite.Zero(sut.CacheStats().Hits)
+			},
+		},
+		{
+			name: "when ttl expires queries again",
+			opts: []osapi.Option{osapi.WithCache(time.Millisecond, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.Load(suite.ctx, "web-01")
+				time.Sleep(5 * time.Millisecond)
+				_, _ = sut.Node.Load(suite.ctx, "web-01")
+
+				suite.Equal(2, suite.requests("GET /node/web-01/load"))
+			},
+		},
+		{
+			name: "when cache is full evicts least recently used",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 2)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.Disk(suite.ctx, "web-01")
+				_, _ = sut.Node.Memory(suite.ctx, "web-01")
+				_, _ = sut.Node.Disk(suite.ctx, "web-01")
+				_, _ = sut.Node.Uptime(suite.ctx, "web-01")
+				_, _ = sut.Node.Disk(suite.ctx, "web-01")
+				_, _ = sut.Node.Memory(suite.ctx, "web-01")
+
+				suite.Equal(1, suite.requests("GET /node/web-01/disk"))
+				suite.Equal(2, suite.requests("GET /node/web-01/memory"))
+				suite.Equal(int64(2), sut.CacheStats().Evictions)
+			},
+		},
+		{
+			name: "when dns is updated invalidates target and broadcasts",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.GetDNS(suite.ctx, "web-01", "eth0")
+				_, _ = sut.Node.Hostname(suite.ctx, "_all")
+				_, _ = sut.Node.Hostname(suite.ctx, "web-02")
+
+				_, err := sut.Node.UpdateDNS(suite.ctx, "web-01", "eth0", []string{"1.1.1.1"}, nil)
+				suite.Require().NoError(err)
+
+				suite.Equal(1, sut.CacheStats().Entries)
+
+				_, _ = sut.Node.GetDNS(suite.ctx, "web-01", "eth0")
+				suite.Equal(2, suite.requests("GET /node/web-01/network/dns/eth0"))
+			},
+		},
+		{
+			name: "when job is submitted invalidates target",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.OS(suite.ctx, "web-01")
+				_, _ = sut.Node.OS(suite.ctx, "web-02")
+
+				_, err := sut.Job.Submit(suite.ctx, osapi.CommandExecOp{Command: "true"}, "web-01")
+				suite.Require().NoError(err)
+
+				_, _ = sut.Node.OS(suite.ctx, "web-01")
+				_, _ = sut.Node.OS(suite.ctx, "web-02")
+
+				suite.Equal(2, suite.requests("GET /node/web-01/os"))
+				suite.Equal(1, suite.requests("GET /node/web-02/os"))
+			},
+		},
+		{
+			name: "when job is retried without target clears cache",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.OS(suite.ctx, "web-01")
+
+				_, err := sut.Job.Retry(suite.ctx, "550e8400-e29b-41d4-a716-446655440000", "")
+				suite.Require().NoError(err)
+
+				suite.Zero(sut.CacheStats().Entries)
+			},
+		},
+		{
+			name: "when agent is drained invalidates agent",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Agent.Get(suite.ctx, "web-01")
+				_, _ = sut.Agent.Get(suite.ctx, "web-01")
+				_, err := sut.Agent.Drain(suite.ctx, "web-01")
+				suite.Require().NoError(err)
+				_, _ = sut.Agent.Get(suite.ctx, "web-01")
+
+				suite.Equal(2, suite.requests("GET /agent/web-01"))
+			},
+		},
+		{
+			name: "when strict host errors fail does not cache",
+			opts: []osapi.Option{
+				osapi.WithCache(time.Minute, 10),
+				osapi.WithStrictHostErrors(),
+			},
+			validateFunc: func(sut *osapi.Client) {
+				for range 2 {
+					_, err := sut.Node.Uptime(suite.ctx, "_all")
+
+					var multi *osapi.MultiHostError
+					suite.True(errors.As(err, &multi))
+				}
+
+				suite.Equal(2, suite.requests("GET /node/_all/uptime"))
+			},
+		},
+		{
+			name: "when invalidated explicitly clears cache",
+			opts: []osapi.Option{osapi.WithCache(time.Minute, 10)},
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.OS(suite.ctx, "web-01")
+				_, _ = sut.Node.OS(suite.ctx, "group:web")
+
+				sut.InvalidateCache("")
+
+				suite.Zero(sut.CacheStats().Entries)
+			},
+		},
+		{
+			name: "when cache is disabled always queries",
+			validateFunc: func(sut *osapi.Client) {
+				_, _ = sut.Node.OS(suite.ctx, "web-01")
+				_, _ = sut.Node.OS(suite.ctx, "web-01")
+				sut.InvalidateCache("web-01")
+
+				suite.Equal(2, suite.requests("GET /node/web-01/os"))
+				suite.Equal(osapi.CacheStats{}, sut.CacheStats())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			tc.validateFunc(suite.client(tc.opts...))
+		})
+	}
+}
+
+func TestCachePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(CachePublicTestSuite))
+}
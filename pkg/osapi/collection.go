@@ -0,0 +1,401 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"math"
+	"slices"
+)
+
+// HostResult is implemented by every per-host result type returned in
+// a Collection.
+type HostResult interface {
+	// Host returns the hostname of the agent that produced the result.
+	Host() string
+
+	// HostError returns the agent-reported error, or an empty string
+	// on success.
+	HostError() string
+}
+
+// Host returns the agent hostname.
+func (r HostnameResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r HostnameResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r NodeStatus) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r NodeStatus) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r DiskResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r DiskResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r MemoryResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r MemoryResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r LoadResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r LoadResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r OSInfoResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r OSInfoResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r UptimeResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r UptimeResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r DNSConfig) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r DNSConfig) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r DNSUpdateResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r DNSUpdateResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r PingResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r PingResult) HostError() string { return r.Error }
+
+// Host returns the agent hostname.
+func (r CommandResult) Host() string { return r.Hostname }
+
+// HostError returns the agent-reported error.
+func (r CommandResult) HostError() string { return r.Error }
+
+// Err returns a *MultiHostError holding an *AgentError for every
+// result that reported an error, or nil if none did. Result types that
+// do not implement HostResult are ignored.
+func (c Collection[T]) Err() error {
+	errs := make(map[string]error)
+
+	for _, r := range c.Results {
+		hr, ok := any(r).(HostResult)
+		if !ok || hr.HostError() == "" {
+			continue
+		}
+
+		errs[hr.Host()] = &AgentError{
+			Hostname: hr.Host(),
+			Message:  hr.HostError(),
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &MultiHostError{Errors: errs}
+}
+
+// collectionResponse wraps a collection in a Response. When strict is
+// set, per-host errors are also returned as a *MultiHostError alongside
+// the response.
+func collectionResponse[T any](
+	strict bool,
+	c Collection[T],
+	rawJSON []byte,
+) (*Response[Collection[T]], error) {
+	resp := NewResponse(c, rawJSON)
+
+	if strict {
+		if err := c.Err(); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// HostValue is a numeric value measured on a host.
+type HostValue struct {
+	Hostname string
+	Value    float64
+}
+
+// Stats summarizes a numeric value across hosts. Results with an error
+// or without the value are excluded.
+type Stats struct {
+	Count int
+	Min   HostValue
+	Max   HostValue
+	Mean  float64
+
+	sorted []float64
+}
+
+// Percentile returns the p-th percentile (0-100) using linear
+// interpolation between closest ranks. It returns NaN when Count is 0.
+func (s Stats) Percentile(
+	p float64,
+) float64 {
+	if len(s.sorted) == 0 {
+		return math.NaN()
+	}
+
+	p = min(max(p, 0), 100)
+	rank := p / 100 * float64(len(s.sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+
+	return s.sorted[lo] + (s.sorted[hi]-s.sorted[lo])*(rank-float64(lo))
+}
+
+// DiskUsage is the usage of a single disk on a host.
+type DiskUsage struct {
+	Hostname    string
+	Disk        Disk
+	UsedPercent float64
+}
+
+// Partition splits results into those that succeeded and those that
+// reported an error, preserving order.
+func Partition[T HostResult](
+	c Collection[T],
+) ([]T, []T) {
+	var succeeded, failed []T
+
+	for _, r := range c.Results {
+		if r.HostError() != "" {
+			failed = append(failed, r)
+		} else {
+			succeeded = append(succeeded, r)
+		}
+	}
+
+	return succeeded, failed
+}
+
+// Aggregate computes Stats over the values extracted from successful
+// results. value returns false for results that carry no value.
+func Aggregate[T HostResult](
+	c Collection[T],
+	value func(T) (float64, bool),
+) Stats {
+	values := Values(c, value)
+
+	var s Stats
+
+	for i, hv := range values {
+		if i == 0 || hv.Value < s.Min.Value {
+			s.Min = hv
+		}
+
+		if i == 0 || hv.Value > s.Max.Value {
+			s.Max = hv
+		}
+
+		s.Mean += hv.Value
+		s.sorted = append(s.sorted, hv.Value)
+	}
+
+	s.Count = len(values)
+	if s.Count > 0 {
+		s.Mean /= float64(s.Count)
+	}
+
+	slices.Sort(s.sorted)
+
+	return s
+}
+
+// Values extracts the value from each successful result that has one.
+func Values[T HostResult](
+	c Collection[T],
+	value func(T) (float64, bool),
+) []HostValue {
+	var values []HostValue
+
+	for _, r := range c.Results {
+		if r.HostError() != "" {
+			continue
+		}
+
+		if v, ok := value(r); ok {
+			values = append(values, HostValue{Hostname: r.Host(), Value: v})
+		}
+	}
+
+	return values
+}
+
+// Above returns the hosts whose value is strictly greater than
+// threshold, in result order.
+func Above[T HostResult](
+	c Collection[T],
+	value func(T) (float64, bool),
+	threshold float64,
+) []HostValue {
+	return slices.DeleteFunc(Values(c, value), func(hv HostValue) bool {
+		return hv.Value <= threshold
+	})
+}
+
+// Below returns the hosts whose value is strictly less than threshold,
+// in result order.
+func Below[T HostResult](
+	c Collection[T],
+	value func(T) (float64, bool),
+	threshold float64,
+) []HostValue {
+	return slices.DeleteFunc(Values(c, value), func(hv HostValue) bool {
+		return hv.Value >= threshold
+	})
+}
+
+// GroupByLabel groups results by the value of the label key on the
+// matching agent. Results from hosts without the label, or not present
+// in agents, are grouped under the empty string.
+func GroupByLabel[T HostResult](
+	c Collection[T],
+	agents []Agent,
+	key string,
+) map[string][]T {
+	labels := make(map[string]string, len(agents))
+	for _, a := range agents {
+		labels[a.Hostname] = a.Labels[key]
+	}
+
+	groups := make(map[string][]T)
+	for _, r := range c.Results {
+		v := labels[r.Host()]
+		groups[v] = append(groups[v], r)
+	}
+
+	return groups
+}
+
+// DisksAbove returns every disk whose used space is strictly greater
+// than percent (0-100) of its total, across successful results.
+func DisksAbove(
+	c Collection[DiskResult],
+	percent float64,
+) []DiskUsage {
+	var out []DiskUsage
+
+	for _, r := range c.Results {
+		if r.Error != "" {
+			continue
+		}
+
+		for _, d := range r.Disks {
+			if d.Total <= 0 {
+				continue
+			}
+
+			used := float64(d.Used) / float64(d.Total) * 100
+			if used > percent {
+				out = append(out, DiskUsage{
+					Hostname:    r.Hostname,
+					Disk:        d,
+					UsedPercent: used,
+				})
+			}
+		}
+	}
+
+	return out
+}
+
+// LoadOneMin extracts the one-minute load average.
+func LoadOneMin(
+	r LoadResult,
+) (float64, bool) {
+	if r.LoadAverage == nil {
+		return 0, false
+	}
+
+	return float64(r.LoadAverage.OneMin), true
+}
+
+// LoadFiveMin extracts the five-minute load average.
+func LoadFiveMin(
+	r LoadResult,
+) (float64, bool) {
+	if r.LoadAverage == nil {
+		return 0, false
+	}
+
+	return float64(r.LoadAverage.FiveMin), true
+}
+
+// LoadFifteenMin extracts the fifteen-minute load average.
+func LoadFifteenMin(
+	r LoadResult,
+) (float64, bool) {
+	if r.LoadAverage == nil {
+		return 0, false
+	}
+
+	return float64(r.LoadAverage.FifteenMin), true
+}
+
+// MemoryUsedPercent extracts used memory as a percentage of total.
+func MemoryUsedPercent(
+	r MemoryResult,
+) (float64, bool) {
+	if r.Memory == nil || r.Memory.Total <= 0 {
+		return 0, false
+	}
+
+	return float64(r.Memory.Used) / float64(r.Memory.Total) * 100, true
+}
+
+// MaxDiskUsedPercent extracts the highest disk usage percentage on a
+// host.
+func MaxDiskUsedPercent(
+	r DiskResult,
+) (float64, bool) {
+	found := false
+	highest := 0.0
+
+	for _, d := range r.Disks {
+		if d.Total <= 0 {
+			continue
+		}
+
+		highest = max(highest, float64(d.Used)/float64(d.Total)*100)
+		found = true
+	}
+
+	return highest, found
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type CollectionPublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CollectionPublicTestSuite) loads() osapi.Collection[osapi.LoadResult] {
+	load := func(host string, one float32) osapi.LoadResult {
+		return osapi.LoadResult{
+			Hostname:    host,
+			LoadAverage: &osapi.LoadAverage{OneMin: one, FiveMin: one / 2, FifteenMin: one / 4},
+		}
+	}
+
+	return osapi.Collection[osapi.LoadResult]{
+		Results: []osapi.LoadResult{
+			load("web-01", 1),
+			load("web-02", 4),
+			{Hostname: "web-03", Error: "agent timeout"},
+			load("db-01", 2),
+			{Hostname: "db-02"},
+			load("db-03", 3),
+		},
+	}
+}
+
+func (suite *CollectionPublicTestSuite) TestPartition() {
+	succeeded, failed := osapi.Partition(suite.loads())
+
+	suite.Len(succeeded, 5)
+	suite.Len(failed, 1)
+	suite.Equal("web-03", failed[0].Host())
+	suite.Equal("agent timeout", failed[0].HostError())
+}
+
+func (suite *CollectionPublicTestSuite) TestAggregate() {
+	tests := []struct {
+		name         string
+		value        func(osapi.LoadResult) (float64, bool)
+		validateFunc func(osapi.Stats)
+	}{
+		{
+			name:  "when aggregating one minute load",
+			value: osapi.LoadOneMin,
+			validateFunc: func(s osapi.Stats) {
+				suite.Equal(4, s.Count)
+				suite.Equal(osapi.HostValue{Hostname: "web-01", Value: 1}, s.Min)
+				suite.Equal(osapi.HostValue{Hostname: "web-02", Value: 4}, s.Max)
+				suite.InDelta(2.5, s.Mean, 0.0001)
+				suite.InDelta(2.5, s.Percentile(50), 0.0001)
+				suite.InDelta(3.7, s.Percentile(90), 0.0001)
+				suite.InDelta(1, s.Percentile(-5), 0.0001)
+				suite.InDelta(4, s.Percentile(150), 0.0001)
+			},
+		},
+		{
+			name:  "when aggregating fifteen minute load",
+			value: osapi.LoadFifteenMin,
+			validateFunc: func(s osapi.Stats) {
+				suite.InDelta(1, s.Max.Value, 0.0001)
+			},
+		},
+		{
+			name: "when no result carries a value",
+			value: func(osapi.LoadResult) (float64, bool) {
+				return 0, false
+			},
+			validateFunc: func(s osapi.Stats) {
+				suite.Zero(s.Count)
+				suite.Zero(s.Mean)
+				suite.True(math.IsNaN(s.Percentile(50)))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(osapi.Aggregate(suite.loads(), tc.value))
+		})
+	}
+}
+
+func (suite *CollectionPublicTestSuite) TestAboveAndBelow() {
+	suite.Equal([]osapi.HostValue{
+		{Hostname: "web-02", Value: 2},
+		{Hostname: "db-03", Value: 1.5},
+	}, osapi.Above(suite.loads(), osapi.LoadFiveMin, 1))
+
+	suite.Equal([]osapi.HostValue{
+		{Hostname: "web-01", Value: 1},
+	}, osapi.Below(suite.loads(), osapi.LoadOneMin, 2))
+}
+
+func (suite *CollectionPublicTestSuite) TestGroupByLabel() {
+	agents := []osapi.Agent{
+		{Hostname: "web-01", Labels: map[string]string{"group": "web"}},
+		{Hostname: "web-02", Labels: map[string]string{"group": "web"}},
+		{Hostname: "db-01", Labels: map[string]string{"group": "db"}},
+		{Hostname: "db-02"},
+	}
+
+	groups := osapi.GroupByLabel(suite.loads(), agents, "group")
+
+	hosts := func(results []osapi.LoadResult) []string {
+		out := make([]string, 0, len(results))
+		for _, r := range results {
+			out = append(out, r.Host())
+		}
+
+		return out
+	}
+
+	suite.Len(groups, 3)
+	suite.Equal([]string{"web-01", "web-02"}, hosts(groups["web"]))
+	suite.Equal([]string{"db-01"}, hosts(groups["db"]))
+	suite.Equal([]string{"web-03", "db-02", "db-03"}, hosts(groups[""]))
+}
+
+func (suite *CollectionPublicTestSuite) TestMemoryUsedPercent() {
+	c := osapi.Collection[osapi.MemoryResult]{
+		Results: []osapi.MemoryResult{
+			{Hostname: "a", Memory: &osapi.Memory{Total: 200, Used: 50}},
+			{Hostname: "b", Memory: &osapi.Memory{Total: 0}},
+			{Hostname: "c"},
+		},
+	}
+
+	suite.Equal([]osapi.HostValue{
+		{Hostname: "a", Value: 25},
+	}, osapi.Values(c, osapi.MemoryUsedPercent))
+}
+
+func (suite *CollectionPublicTestSuite) TestDisks() {
+	c := osapi.Collection[osapi.DiskResult]{
+		Results: []osapi.DiskResult{
+			{
+				Hostname: "web-01",
+				Disks: []osapi.Disk{
+					{Name: "/", Total: 100, Used: 95},
+					{Name: "/boot", Total: 100, Used: 40},
+				},
+			},
+			{
+				Hostname: "web-02",
+				Disks: []osapi.Disk{
+					{Name: "/", Total: 100, Used: 90},
+					{Name: "/empty", Total: 0},
+				},
+			},
+			{Hostname: "web-03", Error: "timeout"},
+		},
+	}
+
+	suite.Equal([]osapi.DiskUsage{
+		{
+			Hostname:    "web-01",
+			Disk:        osapi.Disk{Name: "/", Total: 100, Used: 95},
+			UsedPercent: 95,
+		},
+	}, osapi.DisksAbove(c, 90))
+
+	stats := osapi.Aggregate(c, osapi.MaxDiskUsedPercent)
+	suite.Equal(2, stats.Count)
+	suite.Equal(osapi.HostValue{Hostname: "web-01", Value: 95}, stats.Max)
+
+	_, ok := osapi.MaxDiskUsedPercent(osapi.DiskResult{})
+	suite.False(ok)
+}
+
+func (suite *CollectionPublicTestSuite) TestHostResult() {
+	results := []osapi.HostResult{
+		osapi.HostnameResult{Hostname: "h", Error: "e"},
+		osapi.NodeStatus{Hostname: "h", Error: "e"},
+		osapi.DiskResult{Hostname: "h", Error: "e"},
+		osapi.MemoryResult{Hostname: "h", Error: "e"},
+		osapi.LoadResult{Hostname: "h", Error: "e"},
+		osapi.OSInfoResult{Hostname: "h", Error: "e"},
+		osapi.UptimeResult{Hostname: "h", Error: "e"},
+		osapi.DNSConfig{Hostname: "h", Error: "e"},
+		osapi.DNSUpdateResult{Hostname: "h", Error: "e"},
+		osapi.PingResult{Hostname: "h", Error: "e"},
+		osapi.CommandResult{Hostname: "h", Error: "e"},
+	}
+
+	for _, r := range results {
+		suite.Equal("h", r.Host())
+		suite.Equal("e", r.HostError())
+	}
+}
+
+func (suite *CollectionPublicTestSuite) TestErr() {
+	tests := []struct {
+		name         string
+		c            osapi.Collection[osapi.HostnameResult]
+		validateFunc func(error)
+	}{
+		{
+			name: "when no host reported an error returns nil",
+			c: osapi.Collection[osapi.HostnameResult]{
+				Results: []osapi.HostnameResult{{Hostname: "web-01"}},
+			},
+			validateFunc: func(err error) {
+				suite.NoError(err)
+			},
+		},
+		{
+			name: "when hosts reported errors returns MultiHostError",
+			c: osapi.Collection[osapi.HostnameResult]{
+				Results: []osapi.HostnameResult{
+					{Hostname: "web-01"},
+					{Hostname: "web-02", Error: "unreachable"},
+				},
+			},
+			validateFunc: func(err error) {
+				var multi *osapi.MultiHostError
+				suite.Require().True(errors.As(err, &multi))
+				suite.Equal([]string{"web-02"}, multi.Hosts())
+				suite.Equal("1 host failed: web-02: unreachable", err.Error())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(tc.c.Err())
+		})
+	}
+}
+
+func TestCollectionPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectionPublicTestSuite))
+}
@@ -20,7 +20,11 @@
 
 package osapi
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // APIError is the base error type for OSAPI API errors.
 type APIError struct {
@@ -96,3 +100,96 @@ type UnexpectedStatusError struct {
 func (e *UnexpectedStatusError) Unwrap() error {
 	return &e.APIError
 }
+
+// JobFailedError is returned when a waited-on job ends in the failed
+// or partial_failure state.
+type JobFailedError struct {
+	JobID       string
+	Status      string
+	Message     string
+	AgentStates map[string]AgentState
+}
+
+// Error returns a formatted error string.
+func (e *JobFailedError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = "job " + e.Status
+	}
+
+	return fmt.Sprintf("job %s: %s", e.JobID, msg)
+}
+
+// FailedAgents returns the sorted hostnames of agents whose state is
+// failed.
+func (e *JobFailedError) FailedAgents() []string {
+	var hosts []string
+	for host, state := range e.AgentStates {
+		if state.Status == JobStatusFailed {
+			hosts = append(hosts, host)
+		}
+	}
+
+	sort.Strings(hosts)
+
+	return hosts
+}
+
+// AgentError is an error reported by a single agent in a broadcast
+// response.
+type AgentError struct {
+	Hostname string
+	Message  string
+}
+
+// Error returns a formatted error string.
+func (e *AgentError) Error() string {
+	return e.Hostname + ": " + e.Message
+}
+
+// MultiHostError collects the per-host errors of a broadcast
+// collection, keyed by hostname.
+type MultiHostError struct {
+	Errors map[string]error
+}
+
+// Error returns a formatted error string listing every failed host.
+func (e *MultiHostError) Error() string {
+	hosts := e.Hosts()
+
+	msgs := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		msgs = append(msgs, e.Errors[h].Error())
+	}
+
+	noun := "hosts"
+	if len(hosts) == 1 {
+		noun = "host"
+	}
+
+	return fmt.Sprintf("%d %s failed: %s", len(hosts), noun, strings.Join(msgs, "; "))
+}
+
+// Hosts returns the sorted hostnames that reported an error.
+func (e *MultiHostError) Hosts() []string {
+	hosts := make([]string, 0, len(e.Errors))
+	for h := range e.Errors {
+		hosts = append(hosts, h)
+	}
+
+	sort.Strings(hosts)
+
+	return hosts
+}
+
+// Unwrap returns the per-host errors in hostname order.
+func (e *MultiHostError) Unwrap() []error {
+	hosts := e.Hosts()
+
+	errs := make([]error, 0, len(hosts))
+	for _, h := range hosts {
+		errs = append(errs, e.Errors[h])
+	}
+
+	return errs
+}
@@ -362,6 +362,97 @@ func (suite *ErrorsPublicTestSuite) TestErrorsAsAPIError() {
 	}
 }
 
+func (suite *ErrorsPublicTestSuite) TestJobFailedError() {
+	tests := []struct {
+		name         string
+		err          *osapi.JobFailedError
+		validateFunc func(*osapi.JobFailedError)
+	}{
+		{
+			name: "when message is set formats with message",
+			err: &osapi.JobFailedError{
+				JobID:   "abc",
+				Status:  "failed",
+				Message: "disk full",
+			},
+			validateFunc: func(err *osapi.JobFailedError) {
+				suite.Equal("job abc: disk full", err.Error())
+				suite.Empty(err.FailedAgents())
+			},
+		},
+		{
+			name: "when message is empty formats with status",
+			err: &osapi.JobFailedError{
+				JobID:  "abc",
+				Status: "partial_failure",
+				AgentStates: map[string]osapi.AgentState{
+					"web-02": {Status: "failed", Error: "timeout"},
+					"web-01": {Status: "completed"},
+					"web-03": {Status: "failed"},
+				},
+			},
+			validateFunc: func(err *osapi.JobFailedError) {
+				suite.Equal("job abc: job partial_failure", err.Error())
+				suite.Equal([]string{"web-02", "web-03"}, err.FailedAgents())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(tc.err)
+		})
+	}
+}
+
+func (suite *ErrorsPublicTestSuite) TestMultiHostError() {
+	tests := []struct {
+		name         string
+		err          *osapi.MultiHostError
+		validateFunc func(*osapi.MultiHostError)
+	}{
+		{
+			name: "when one host failed formats singular",
+			err: &osapi.MultiHostError{
+				Errors: map[string]error{
+					"web-01": &osapi.AgentError{Hostname: "web-01", Message: "timeout"},
+				},
+			},
+			validateFunc: func(err *osapi.MultiHostError) {
+				suite.Equal("1 host failed: web-01: timeout", err.Error())
+				suite.Equal([]string{"web-01"}, err.Hosts())
+			},
+		},
+		{
+			name: "when several hosts failed formats in hostname order",
+			err: &osapi.MultiHostError{
+				Errors: map[string]error{
+					"web-02": &osapi.AgentError{Hostname: "web-02", Message: "disk full"},
+					"web-01": &osapi.AgentError{Hostname: "web-01", Message: "timeout"},
+				},
+			},
+			validateFunc: func(err *osapi.MultiHostError) {
+				suite.Equal(
+					"2 hosts failed: web-01: timeout; web-02: disk full",
+					err.Error(),
+				)
+				suite.Equal([]string{"web-01", "web-02"}, err.Hosts())
+				suite.Len(err.Unwrap(), 2)
+
+				var agentErr *osapi.AgentError
+				suite.True(errors.As(err, &agentErr))
+				suite.Equal("web-01", agentErr.Hostname)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(tc.err)
+		})
+	}
+}
+
 func TestErrorsPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(ErrorsPublicTestSuite))
 }
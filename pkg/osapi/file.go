@@ -21,13 +21,17 @@
 package osapi
 
 import (
-	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/osapi-io/osapi-sdk/pkg/osapi/gen"
 )
@@ -35,8 +39,13 @@ import (
 // UploadOption configures Upload behavior.
 type UploadOption func(*uploadOptions)
 
+// cleanupTimeout bounds the requests that remove a partially written
+// object after an upload is aborted.
+const cleanupTimeout = 10 * time.Second
+
 type uploadOptions struct {
-	force bool
+	force    bool
+	progress func(sent, total int64)
 }
 
 // WithForce bypasses both SDK-side pre-check and server-side digest
@@ -45,15 +54,39 @@ func WithForce() UploadOption {
 	return func(o *uploadOptions) { o.force = true }
 }
 
+// WithProgress reports upload progress. fn is called from the goroutine
+// streaming the body after every chunk read from the file, with the
+// bytes sent so far and the total size, or -1 when the reader is not
+// seekable and the size is unknown.
+func WithProgress(
+	fn func(sent, total int64),
+) UploadOption {
+	return func(o *uploadOptions) { o.progress = fn }
+}
+
 // FileService provides file management operations for the Object Store.
 type FileService struct {
 	client *gen.ClientWithResponses
+	node   *NodeService
+	agents *AgentService
 }
 
 // Upload uploads a file to the Object Store via multipart/form-data.
-// By default, it computes SHA-256 locally and compares against the
-// stored hash to skip the upload when content is unchanged. Use
-// WithForce to bypass this check.
+// The content is streamed to the API rather than buffered in memory.
+// When file is an io.ReadSeeker (such as an *os.File), Upload first
+// computes its SHA-256 and compares it against the stored hash to skip
+// the upload when content is unchanged, then rewinds it for streaming.
+// Other readers are streamed directly and rely on the server-side
+// digest check. Use WithForce to bypass both checks.
+//
+// If pre-hashed content changes while it is streamed, the body is
+// aborted before it completes so the server never stores it.
+//
+// If ctx ends mid-upload and the pre-check recorded what was stored
+// before, Upload removes any partially written object left under name.
+// An object holding either the previous or the uploaded content is
+// kept, since the server may have committed the upload before ctx
+// ended.
 func (s *FileService) Upload(
 	ctx context.Context,
 	name string,
@@ -66,42 +99,66 @@ func (s *FileService) Upload(
 		o(&options)
 	}
 
-	// Buffer file content for hashing and multipart construction.
-	fileData, err := io.ReadAll(file)
-	if err != nil {
-		return nil, fmt.Errorf("read file: %w", err)
-	}
+	// Pre-hash seekable content so unchanged files are never sent.
+	var (
+		sha256Hex string
+		total     int64 = -1
+		// checked is set when the pre-check learned the stored hash,
+		// which is empty when no object existed.
+		checked  bool
+		previous string
+	)
 
-	// Compute SHA-256 locally.
-	hash := sha256.Sum256(fileData)
-	sha256Hex := fmt.Sprintf("%x", hash)
-
-	// SDK-side pre-check: skip upload if content unchanged.
-	// Skipped when force is set.
-	if !options.force {
-		existing, err := s.Get(ctx, name)
-		if err == nil && existing.Data.SHA256 == sha256Hex {
-			return NewResponse(FileUpload{
-				Name:        name,
-				SHA256:      sha256Hex,
-				Size:        len(fileData),
-				Changed:     false,
-				ContentType: contentType,
-			}, nil), nil
+	if rs, ok := file.(io.ReadSeeker); ok {
+		sum, size, err := hashSeeker(rs)
+		if err != nil {
+			return nil, fmt.Errorf("read file: %w", err)
 		}
-		// On error (404, network, etc.) fall through to upload.
-	}
 
-	// Build multipart body from buffered content.
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+		sha256Hex = sum
+		total = size
+
+		// SDK-side pre-check: skip upload if content unchanged.
+		// Skipped when force is set.
+		if !options.force {
+			existing, err := s.Get(ctx, name)
+			if err == nil && existing.Data.SHA256 == sha256Hex {
+				return NewResponse(FileUpload{
+					Name:        name,
+					SHA256:      sha256Hex,
+					Size:        int(size),
+					Changed:     false,
+					ContentType: contentType,
+				}, nil), nil
+			}
+
+			var notFound *NotFoundError
+			switch {
+			case err == nil:
+				checked, previous = true, existing.Data.SHA256
+			case errors.As(err, &notFound):
+				checked = true
+			}
+			// On other errors (network, etc.) fall through to upload.
+		}
+	}
 
-	_ = writer.WriteField("name", name)
-	_ = writer.WriteField("content_type", contentType)
+	// Stream the multipart body through a pipe, hashing as it is sent.
+	src := &hashingReader{
+		r:        file,
+		h:        sha256.New(),
+		want:     sha256Hex,
+		total:    total,
+		progress: options.progress,
+	}
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
 
-	part, _ := writer.CreateFormFile("file", name)
-	_, _ = part.Write(fileData)
-	_ = writer.Close()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = pw.CloseWithError(writeMultipart(writer, name, contentType, src))
+	}()
 
 	// Pass force as query param.
 	params := &gen.PostFileParams{}
@@ -113,10 +170,32 @@ func (s *FileService) Upload(
 		ctx,
 		params,
 		writer.FormDataContentType(),
-		body,
+		pr,
 	)
+
+	// Unblock the writer if the request ended before the body was
+	// consumed, then wait so src is safe to inspect.
+	_ = pr.Close()
+	<-done
+
+	if src.changed {
+		return nil, fmt.Errorf("upload file %s: content changed during upload", name)
+	}
+
+	if src.err != nil {
+		return nil, fmt.Errorf("read file: %w", src.err)
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("upload file: %w", err)
+		err = fmt.Errorf("upload file: %w", err)
+
+		if ctx.Err() != nil && checked {
+			if cleanupErr := s.cleanupPartial(ctx, name, previous, sha256Hex); cleanupErr != nil {
+				err = errors.Join(err, cleanupErr)
+			}
+		}
+
+		return nil, err
 	}
 
 	if err := checkError(
@@ -140,6 +219,156 @@ func (s *FileService) Upload(
 	return NewResponse(fileUploadFromGen(resp.JSON201), resp.Body), nil
 }
 
+// UploadFile uploads the file at path without loading it into memory.
+// The content is hashed from disk first, so unchanged files are skipped
+// as with Upload. An empty name uses the base name of path.
+func (s *FileService) UploadFile(
+	ctx context.Context,
+	name string,
+	contentType string,
+	path string,
+	opts ...UploadOption,
+) (*Response[FileUpload], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if name == "" {
+		name = filepath.Base(path)
+	}
+
+	return s.Upload(ctx, name, contentType, f, opts...)
+}
+
+// cleanupPartial deletes the object stored under name after an aborted
+// upload, unless it holds the previous content or the complete uploaded
+// content, either of which is a valid object. An empty previous hash
+// means no object existed before the upload. The requests run on a
+// fresh deadline because ctx is already done.
+func (s *FileService) cleanupPartial(
+	ctx context.Context,
+	name string,
+	previous string,
+	uploaded string,
+) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	existing, err := s.Get(ctx, name)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return nil
+		}
+
+		return fmt.Errorf("clean up %s: %w", name, err)
+	}
+
+	switch existing.Data.SHA256 {
+	case previous, uploaded:
+		return nil
+	}
+
+	if _, err := s.Delete(ctx, name); err != nil {
+		return fmt.Errorf("clean up %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// hashSeeker returns the SHA-256 and size of the remaining content of
+// rs, then seeks back to where it started.
+func hashSeeker(
+	rs io.ReadSeeker,
+) (string, int64, error) {
+	start, err := rs.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, err
+	}
+
+	h := sha256.New()
+	size, err := io.Copy(h, rs)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if _, err := rs.Seek(start, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// writeMultipart writes the upload form fields and file content.
+func writeMultipart(
+	writer *multipart.Writer,
+	name string,
+	contentType string,
+	file io.Reader,
+) error {
+	if err := writer.WriteField("name", name); err != nil {
+		return err
+	}
+
+	if err := writer.WriteField("content_type", contentType); err != nil {
+		return err
+	}
+
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// hashingReader hashes content as it is read, reports progress, and
+// records read errors so they can be told apart from a closed pipe.
+// When want is set, reaching EOF with a different hash fails the read
+// so the request body never completes.
+type hashingReader struct {
+	r        io.Reader
+	h        hash.Hash
+	want     string
+	sent     int64
+	total    int64
+	progress func(sent, total int64)
+	err      error
+	changed  bool
+}
+
+func (r *hashingReader) Read(
+	p []byte,
+) (int, error) {
+	n, err := r.r.Read(p)
+	r.h.Write(p[:n])
+
+	r.sent += int64(n)
+	if n > 0 && r.progress != nil {
+		r.progress(r.sent, r.total)
+	}
+
+	switch {
+	case errors.Is(err, io.EOF) && r.want != "" && r.sum() != r.want:
+		r.changed = true
+		err = errors.New("content changed during upload")
+	case err != nil && !errors.Is(err, io.EOF):
+		r.err = err
+	}
+
+	return n, err
+}
+
+func (r *hashingReader) sum() string {
+	return hex.EncodeToString(r.h.Sum(nil))
+}
+
 // List retrieves all files stored in the Object Store.
 func (s *FileService) List(
 	ctx context.Context,
@@ -238,13 +467,12 @@ func (s *FileService) Changed(
 	name string,
 	file io.Reader,
 ) (*Response[FileChanged], error) {
-	fileData, err := io.ReadAll(file)
-	if err != nil {
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
 		return nil, fmt.Errorf("read file: %w", err)
 	}
 
-	hash := sha256.Sum256(fileData)
-	sha256Hex := fmt.Sprintf("%x", hash)
+	sha256Hex := hex.EncodeToString(h.Sum(nil))
 
 	existing, err := s.Get(ctx, name)
 	if err != nil {
@@ -0,0 +1,293 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DriftCheck names a deployed file to check: an Object Store object,
+// the path it is deployed to, and the target selecting the hosts.
+type DriftCheck struct {
+	ObjectName string
+	Path       string
+	Target     string
+}
+
+// DriftState is the state of a deployed file on a single host.
+type DriftState string
+
+const (
+	// DriftInSync means the host holds the current object content.
+	DriftInSync DriftState = "in-sync"
+	// DriftDrifted means the host holds different content.
+	DriftDrifted DriftState = "drifted"
+	// DriftMissing means the file does not exist on the host.
+	DriftMissing DriftState = "missing"
+)
+
+// DriftHost is the outcome of a drift check on a single host.
+type DriftHost struct {
+	Hostname string
+
+	// State is empty when Err is set.
+	State DriftState
+
+	// SHA256 is the hash of the file on the host.
+	SHA256 string
+
+	// Err is the error returned for this host, if any.
+	Err error
+}
+
+// DriftResult is the outcome of one DriftCheck across its hosts.
+type DriftResult struct {
+	Check DriftCheck
+
+	// StoreSHA256 is the current hash of the object in the Object
+	// Store.
+	StoreSHA256 string
+
+	// Hosts holds the per-host outcome in hostname order.
+	Hosts []DriftHost
+
+	// Err is set when the check could not run at all, e.g. because the
+	// object does not exist or no agent matches the target.
+	Err error
+}
+
+// InSync returns the hostnames holding the current content.
+func (r DriftResult) InSync() []string {
+	return r.hosts(DriftInSync)
+}
+
+// Drifted returns the hostnames holding different content.
+func (r DriftResult) Drifted() []string {
+	return r.hosts(DriftDrifted)
+}
+
+// Missing returns the hostnames without the file.
+func (r DriftResult) Missing() []string {
+	return r.hosts(DriftMissing)
+}
+
+func (r DriftResult) hosts(
+	state DriftState,
+) []string {
+	var hosts []string
+
+	for _, h := range r.Hosts {
+		if h.Err == nil && h.State == state {
+			hosts = append(hosts, h.Hostname)
+		}
+	}
+
+	return hosts
+}
+
+// DriftReport is the outcome of a fleet drift check.
+type DriftReport struct {
+	CheckedAt time.Time
+	Results   []DriftResult
+}
+
+// HasDrift reports whether any host is drifted or missing a file.
+func (r *DriftReport) HasDrift() bool {
+	for _, res := range r.Results {
+		if len(res.Drifted()) > 0 || len(res.Missing()) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err joins the check and host errors, or returns nil if every host
+// was checked.
+func (r *DriftReport) Err() error {
+	var errs []error
+
+	for _, res := range r.Results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", res.Check.Path, res.Err))
+		}
+
+		for _, h := range res.Hosts {
+			if h.Err != nil {
+				errs = append(errs, fmt.Errorf("%s on %s: %w", res.Check.Path, h.Hostname, h.Err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a line per check summarizing its hosts, followed by
+// the drifted and missing hostnames.
+func (r *DriftReport) String() string {
+	var b strings.Builder
+
+	for _, res := range r.Results {
+		fmt.Fprintf(&b, "%s (%s -> %s): ", res.Check.ObjectName, res.Check.Target, res.Check.Path)
+
+		if res.Err != nil {
+			fmt.Fprintf(&b, "error: %s\n", res.Err)
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "%d in-sync, %d drifted, %d missing\n",
+			len(res.InSync()), len(res.Drifted()), len(res.Missing()))
+
+		for _, h := range res.Drifted() {
+			fmt.Fprintf(&b, "  drifted %s\n", h)
+		}
+
+		for _, h := range res.Missing() {
+			fmt.Fprintf(&b, "  missing %s\n", h)
+		}
+	}
+
+	return b.String()
+}
+
+// Drift compares the file deployed at each check's path on every host
+// its target resolves to against the object's current hash in the
+// Object Store. A host whose file hash differs is drifted; one without
+// the file is missing. Rendered templates never match the stored
+// template, so for "template" objects the state the agent reports for
+// its last deploy is used instead. Status requests run concurrently,
+// limited by WithConcurrency. The returned error is non-nil only if
+// the agents could not be listed; per-check and per-host errors are
+// recorded in the report.
+func (s *FileService) Drift(
+	ctx context.Context,
+	checks []DriftCheck,
+	opts ...BulkOption,
+) (*DriftReport, error) {
+	options := newBulkOptions(opts)
+
+	agents, err := s.agents.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("check drift: %w", err)
+	}
+
+	report := &DriftReport{
+		CheckedAt: time.Now(),
+		Results:   make([]DriftResult, len(checks)),
+	}
+
+	sem := make(chan struct{}, options.concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+		res := &report.Results[i]
+		res.Check = check
+
+		meta, err := s.Get(ctx, check.ObjectName)
+		if err != nil {
+			res.Err = err
+
+			continue
+		}
+
+		res.StoreSHA256 = meta.Data.SHA256
+		template := meta.Data.ContentType == "template"
+
+		matched := MatchAgents(agents.Data.Agents, check.Target)
+		if len(matched) == 0 {
+			res.Err = fmt.Errorf("no agents match target %q", check.Target)
+
+			continue
+		}
+
+		res.Hosts = make([]DriftHost, len(matched))
+		for j, a := range matched {
+			res.Hosts[j].Hostname = a.Hostname
+		}
+
+		sort.Slice(res.Hosts, func(a, b int) bool {
+			return res.Hosts[a].Hostname < res.Hosts[b].Hostname
+		})
+
+		for j := range res.Hosts {
+			h := &res.Hosts[j]
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				h.Err = ctx.Err()
+
+				continue
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				s.driftHost(ctx, h, check.Path, res.StoreSHA256, template)
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	return report, nil
+}
+
+// driftHost fills in the state of the file at path on h.
+func (s *FileService) driftHost(
+	ctx context.Context,
+	h *DriftHost,
+	path string,
+	storeSHA string,
+	template bool,
+) {
+	resp, err := s.node.FileStatus(ctx, h.Hostname, path)
+	if err != nil {
+		h.Err = err
+
+		return
+	}
+
+	h.SHA256 = resp.Data.SHA256
+
+	switch {
+	case resp.Data.Status == string(DriftMissing) || h.SHA256 == "":
+		h.State = DriftMissing
+	case template:
+		h.State = DriftState(resp.Data.Status)
+	case h.SHA256 == storeSHA:
+		h.State = DriftInSync
+	default:
+		h.State = DriftDrifted
+	}
+}
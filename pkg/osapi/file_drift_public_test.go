@@ -0,0 +1,213 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type FileDriftPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+}
+
+func (suite *FileDriftPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+// driftServer serves three agents, two stored objects, and file status
+// per host from deployed, keyed by hostname.
+func (suite *FileDriftPublicTestSuite) driftServer(
+	deployed map[string]map[string]any,
+	listCode int,
+) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/agent":
+			if listCode != 0 {
+				w.WriteHeader(listCode)
+				_, _ = w.Write([]byte(`{"error":"failed"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"agents": []map[string]any{
+					{"hostname": "web-02", "status": "Ready", "labels": map[string]string{"role": "web"}},
+					{"hostname": "web-01", "status": "Ready", "labels": map[string]string{"role": "web"}},
+					{"hostname": "web-03", "status": "Ready", "labels": map[string]string{"role": "web"}},
+					{"hostname": "db-01", "status": "Ready", "labels": map[string]string{"role": "db"}},
+				},
+				"total": 4,
+			})
+		case r.URL.Path == "/file/nginx.conf":
+			_, _ = w.Write([]byte(`{"name":"nginx.conf","sha256":"current","size":1,"content_type":"raw"}`))
+		case r.URL.Path == "/file/app.conf.tmpl":
+			_, _ = w.Write([]byte(`{"name":"app.conf.tmpl","sha256":"tmpl","size":1,"content_type":"template"}`))
+		case strings.HasPrefix(r.URL.Path, "/file/"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"file not found"}`))
+		case strings.HasSuffix(r.URL.Path, "/file/status"):
+			host := strings.Split(r.URL.Path, "/")[2]
+			status, ok := deployed[host]
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"agent unreachable"}`))
+				return
+			}
+			status["job_id"] = "00000000-0000-0000-0000-000000000001"
+			status["hostname"] = host
+			status["path"] = "/etc/nginx/nginx.conf"
+			_ = json.NewEncoder(w).Encode(status)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+}
+
+func (suite *FileDriftPublicTestSuite) TestDrift() {
+	tests := []struct {
+		name         string
+		checks       []osapi.DriftCheck
+		deployed     map[string]map[string]any
+		listCode     int
+		validateFunc func(*osapi.DriftReport, error)
+	}{
+		{
+			name: "when hosts differ classifies in-sync, drifted, and missing",
+			checks: []osapi.DriftCheck{
+				{ObjectName: "nginx.conf", Path: "/etc/nginx/nginx.conf", Target: "role:web"},
+			},
+			deployed: map[string]map[string]any{
+				"web-01": {"status": "in-sync", "sha256": "current"},
+				"web-02": {"status": "in-sync", "sha256": "previous"},
+				"web-03": {"status": "missing"},
+			},
+			validateFunc: func(report *osapi.DriftReport, err error) {
+				suite.Require().NoError(err)
+				suite.Require().Len(report.Results, 1)
+
+				res := report.Results[0]
+				suite.Equal("current", res.StoreSHA256)
+				suite.Equal([]string{"web-01"}, res.InSync())
+				suite.Equal([]string{"web-02"}, res.Drifted())
+				suite.Equal([]string{"web-03"}, res.Missing())
+				suite.True(report.HasDrift())
+				suite.NoError(report.Err())
+				suite.Equal(
+					"nginx.conf (role:web -> /etc/nginx/nginx.conf): 1 in-sync, 1 drifted, 1 missing\n"+
+						"  drifted web-02\n"+
+						"  missing web-03\n",
+					report.String(),
+				)
+			},
+		},
+		{
+			name: "when every host matches reports no drift",
+			checks: []osapi.DriftCheck{
+				{ObjectName: "nginx.conf", Path: "/etc/nginx/nginx.conf", Target: "db-01"},
+			},
+			deployed: map[string]map[string]any{
+				"db-01": {"status": "in-sync", "sha256": "current"},
+			},
+			validateFunc: func(report *osapi.DriftReport, err error) {
+				suite.Require().NoError(err)
+				suite.False(report.HasDrift())
+				suite.False(report.CheckedAt.IsZero())
+			},
+		},
+		{
+			name: "when object is a template uses agent status",
+			checks: []osapi.DriftCheck{
+				{ObjectName: "app.conf.tmpl", Path: "/etc/app.conf", Target: "role:web"},
+			},
+			deployed: map[string]map[string]any{
+				"web-01": {"status": "in-sync", "sha256": "rendered-1"},
+				"web-02": {"status": "drifted", "sha256": "edited"},
+				"web-03": {"status": "in-sync", "sha256": "rendered-3"},
+			},
+			validateFunc: func(report *osapi.DriftReport, err error) {
+				suite.Require().NoError(err)
+				suite.Equal([]string{"web-01", "web-03"}, report.Results[0].InSync())
+				suite.Equal([]string{"web-02"}, report.Results[0].Drifted())
+			},
+		},
+		{
+			name: "when checks fail records errors per check and host",
+			checks: []osapi.DriftCheck{
+				{ObjectName: "gone.conf", Path: "/etc/gone.conf", Target: "_all"},
+				{ObjectName: "nginx.conf", Path: "/etc/nginx/nginx.conf", Target: "role:cache"},
+				{ObjectName: "nginx.conf", Path: "/etc/nginx/nginx.conf", Target: "db-01"},
+			},
+			validateFunc: func(report *osapi.DriftReport, err error) {
+				suite.Require().NoError(err)
+
+				var notFound *osapi.NotFoundError
+				suite.ErrorAs(report.Results[0].Err, &notFound)
+				suite.ErrorContains(report.Results[1].Err, `no agents match target "role:cache"`)
+				suite.Error(report.Results[2].Hosts[0].Err)
+				suite.Empty(report.Results[2].Hosts[0].State)
+				suite.False(report.HasDrift())
+
+				suite.ErrorContains(report.Err(), "/etc/nginx/nginx.conf on db-01")
+				suite.Contains(report.String(), "gone.conf (_all -> /etc/gone.conf): error:")
+			},
+		},
+		{
+			name:     "when agents cannot be listed returns error",
+			listCode: http.StatusInternalServerError,
+			validateFunc: func(report *osapi.DriftReport, err error) {
+				suite.Nil(report)
+				suite.ErrorContains(err, "check drift")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := suite.driftServer(tc.deployed, tc.listCode)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token", osapi.WithLogger(slog.Default()))
+
+			report, err := sut.File.Drift(suite.ctx, tc.checks, osapi.WithConcurrency(2))
+			tc.validateFunc(report, err)
+		})
+	}
+}
+
+func TestFileDriftPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(FileDriftPublicTestSuite))
+}
@@ -30,6 +30,9 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -51,14 +54,23 @@ func (suite *FilePublicTestSuite) TestUpload() {
 	fileContent := []byte("content")
 	hash := sha256.Sum256(fileContent)
 	contentSHA := fmt.Sprintf("%x", hash)
+	bigContent := bytes.Repeat([]byte("x"), 4*1024*1024)
+	bigSHA := fmt.Sprintf("%x", sha256.Sum256(bigContent))
 
 	tests := []struct {
-		name         string
-		handler      http.HandlerFunc
-		serverURL    string
-		file         io.Reader
-		opts         []osapi.UploadOption
-		validateFunc func(*osapi.Response[osapi.FileUpload], error)
+		name      string
+		handler   http.HandlerFunc
+		serverURL string
+		file      io.Reader
+		opts      []osapi.UploadOption
+		// store serves the row from an uploadState instead of handler.
+		store          bool
+		stored         string
+		partial        bool
+		cancelOnCommit bool
+		// cancelAfter cancels the upload once more bytes were sent.
+		cancelAfter  int64
+		validateFunc func(*osapi.Response[osapi.FileUpload], error, *uploadState)
 	}{
 		{
 			name: "when uploading new file returns result",
@@ -76,7 +88,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 					),
 				)
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.NoError(err)
 				suite.NotNil(resp)
 				suite.Equal("nginx.conf", resp.Data.Name)
@@ -102,7 +114,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 				w.WriteHeader(http.StatusInternalServerError)
 				_, _ = w.Write([]byte(`{"error":"unexpected POST"}`))
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.NoError(err)
 				suite.NotNil(resp)
 				suite.Equal("nginx.conf", resp.Data.Name)
@@ -130,7 +142,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 					),
 				)
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.NoError(err)
 				suite.NotNil(resp)
 				suite.True(resp.Data.Changed)
@@ -148,7 +160,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 				w.WriteHeader(http.StatusConflict)
 				_, _ = w.Write([]byte(`{"error":"file already exists"}`))
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.Error(err)
 				suite.Nil(resp)
 
@@ -169,7 +181,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 				w.WriteHeader(http.StatusBadRequest)
 				_, _ = w.Write([]byte(`{"error":"name is required"}`))
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.Error(err)
 				suite.Nil(resp)
 
@@ -185,7 +197,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 				w.WriteHeader(http.StatusForbidden)
 				_, _ = w.Write([]byte(`{"error":"forbidden"}`))
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.Error(err)
 				suite.Nil(resp)
 
@@ -197,7 +209,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 		{
 			name:      "when client HTTP call fails returns error",
 			serverURL: "http://127.0.0.1:0",
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.Error(err)
 				suite.Nil(resp)
 			},
@@ -211,7 +223,7 @@ func (suite *FilePublicTestSuite) TestUpload() {
 				}
 				w.WriteHeader(http.StatusCreated)
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.Error(err)
 				suite.Nil(resp)
 
@@ -227,25 +239,142 @@ func (suite *FilePublicTestSuite) TestUpload() {
 			handler: func(w http.ResponseWriter, _ *http.Request) {
 				w.WriteHeader(http.StatusCreated)
 			},
-			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, _ *uploadState) {
 				suite.Error(err)
 				suite.Nil(resp)
 				suite.Contains(err.Error(), "read file")
 			},
 		},
+		{
+			name:  "when reader is not seekable streams without pre-check",
+			store: true,
+			file:  io.MultiReader(bytes.NewReader(bigContent)),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.True(resp.Data.Changed)
+				suite.Equal([]string{http.MethodPost}, st.methods)
+				suite.Equal(bigSHA, st.stored)
+				suite.Equal(int64(-1), st.total)
+			},
+		},
+		{
+			name:  "when content changes during upload aborts before server stores it",
+			store: true,
+			file: &changingReader{
+				first:  bytes.NewReader(bigContent),
+				second: bytes.NewReader(bytes.ToUpper(bigContent[:10])),
+			},
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.Nil(resp)
+				suite.ErrorContains(err, "upload file nginx.conf: content changed during upload")
+				suite.Empty(st.stored)
+			},
+		},
+		{
+			name:  "when progress is requested reports bytes sent and total size",
+			store: true,
+			file:  bytes.NewReader(bigContent),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.Require().NoError(err)
+				suite.NotNil(resp)
+				suite.Positive(st.progress)
+				suite.Equal(int64(len(bigContent)), st.sent)
+				suite.Equal(int64(len(bigContent)), st.total)
+			},
+		},
+		{
+			name:        "when new object is aborted deletes partial object",
+			store:       true,
+			partial:     true,
+			cancelAfter: 1024 * 1024,
+			file:        bytes.NewReader(bigContent),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.Nil(resp)
+				suite.ErrorIs(err, context.Canceled)
+				suite.True(st.deleted)
+			},
+		},
+		{
+			name:           "when server committed before cancel keeps new object",
+			store:          true,
+			cancelOnCommit: true,
+			file:           bytes.NewReader(bigContent),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.Nil(resp)
+				suite.ErrorIs(err, context.Canceled)
+				suite.False(st.deleted)
+				suite.Equal(bigSHA, st.stored)
+			},
+		},
+		{
+			name:           "when server committed before cancel keeps replaced object",
+			store:          true,
+			stored:         "old",
+			cancelOnCommit: true,
+			file:           bytes.NewReader(bigContent),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.Nil(resp)
+				suite.ErrorIs(err, context.Canceled)
+				suite.False(st.deleted)
+				suite.Equal(bigSHA, st.stored)
+			},
+		},
+		{
+			name:        "when existing object was replaced deletes partial object",
+			store:       true,
+			stored:      "old",
+			partial:     true,
+			cancelAfter: 1024 * 1024,
+			file:        bytes.NewReader(bigContent),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.Nil(resp)
+				suite.ErrorIs(err, context.Canceled)
+				suite.True(st.deleted)
+			},
+		},
+		{
+			name:        "when force skips pre-check cancel leaves store untouched",
+			store:       true,
+			stored:      "old",
+			partial:     true,
+			cancelAfter: 1024 * 1024,
+			opts:        []osapi.UploadOption{osapi.WithForce()},
+			file:        bytes.NewReader(bigContent),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error, st *uploadState) {
+				suite.Nil(resp)
+				suite.ErrorIs(err, context.Canceled)
+				suite.False(st.deleted)
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		suite.Run(tc.name, func() {
+			ctx, cancel := context.WithCancel(suite.ctx)
+			defer cancel()
+
+			st := &uploadState{
+				stored:         tc.stored,
+				partial:        tc.partial,
+				cancelOnCommit: tc.cancelOnCommit,
+				cancel:         cancel,
+			}
+
 			var (
 				serverURL string
 				cleanup   func()
 			)
 
-			if tc.serverURL != "" {
+			switch {
+			case tc.serverURL != "":
 				serverURL = tc.serverURL
 				cleanup = func() {}
-			} else {
+			case tc.store:
+				server := httptest.NewServer(http.HandlerFunc(st.serve))
+				serverURL = server.URL
+				cleanup = server.Close
+			default:
 				server := httptest.NewServer(tc.handler)
 				serverURL = server.URL
 				cleanup = server.Close
@@ -263,14 +392,33 @@ func (suite *FilePublicTestSuite) TestUpload() {
 				file = bytes.NewReader(fileContent)
 			}
 
+			opts := tc.opts
+			if tc.store {
+				opts = append([]osapi.UploadOption{
+					osapi.WithProgress(func(sent, total int64) {
+						st.mu.Lock()
+						defer st.mu.Unlock()
+						suite.GreaterOrEqual(sent, st.sent)
+						st.progress++
+						st.sent, st.total = sent, total
+						if tc.cancelAfter > 0 && sent > tc.cancelAfter {
+							cancel()
+						}
+					}),
+				}, opts...)
+			}
+
 			resp, err := sut.File.Upload(
-				suite.ctx,
+				ctx,
 				"nginx.conf",
 				"raw",
 				file,
-				tc.opts...,
+				opts...,
 			)
-			tc.validateFunc(resp, err)
+
+			st.mu.Lock()
+			defer st.mu.Unlock()
+			tc.validateFunc(resp, err, st)
 		})
 	}
 }
@@ -774,6 +922,204 @@ func (suite *FilePublicTestSuite) TestChanged() {
 	}
 }
 
+func (suite *FilePublicTestSuite) TestUploadFile() {
+	fileContent := []byte("content")
+	contentSHA := fmt.Sprintf("%x", sha256.Sum256(fileContent))
+
+	path := filepath.Join(suite.T().TempDir(), "nginx.conf")
+	suite.Require().NoError(os.WriteFile(path, fileContent, 0o600))
+
+	tests := []struct {
+		name         string
+		path         string
+		storedSHA    string
+		validateFunc func(*osapi.Response[osapi.FileUpload], error)
+	}{
+		{
+			name:      "when content is unchanged skips upload",
+			path:      path,
+			storedSHA: contentSHA,
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.Equal("nginx.conf", resp.Data.Name)
+				suite.Equal(7, resp.Data.Size)
+				suite.False(resp.Data.Changed)
+			},
+		},
+		{
+			name:      "when content differs uploads file",
+			path:      path,
+			storedSHA: "stale",
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.Equal(contentSHA, resp.Data.SHA256)
+				suite.True(resp.Data.Changed)
+			},
+		},
+		{
+			name: "when file does not exist returns error",
+			path: filepath.Join(suite.T().TempDir(), "missing"),
+			validateFunc: func(resp *osapi.Response[osapi.FileUpload], err error) {
+				suite.Nil(resp)
+				suite.ErrorContains(err, "open file")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					if r.Method == http.MethodGet {
+						w.WriteHeader(http.StatusOK)
+						_, _ = fmt.Fprintf(w,
+							`{"name":"nginx.conf","sha256":"%s","size":7,"content_type":"raw"}`,
+							tc.storedSHA,
+						)
+						return
+					}
+
+					suite.Equal("nginx.conf", r.FormValue("name"))
+					w.WriteHeader(http.StatusCreated)
+					_, _ = fmt.Fprintf(w,
+						`{"name":"nginx.conf","sha256":"%s","size":7,"changed":true,"content_type":"raw"}`,
+						contentSHA,
+					)
+				}),
+			)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token", osapi.WithLogger(slog.Default()))
+
+			resp, err := sut.File.UploadFile(suite.ctx, "", "raw", tc.path)
+			tc.validateFunc(resp, err)
+		})
+	}
+}
+
+// uploadState is a fake Object Store for TestUpload rows that inspect
+// what the server received. It also records upload progress.
+type uploadState struct {
+	mu sync.Mutex
+	// methods lists the request methods the server received.
+	methods []string
+	// stored is the SHA-256 of the stored object, or "" when absent.
+	stored  string
+	deleted bool
+	// partial makes POST write the object as the body arrives instead
+	// of storing the complete upload.
+	partial bool
+	// cancelOnCommit cancels the upload once the object is stored,
+	// before the response is sent.
+	cancelOnCommit bool
+	cancel         context.CancelFunc
+
+	progress int
+	sent     int64
+	total    int64
+}
+
+func (st *uploadState) serve(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	st.mu.Lock()
+	st.methods = append(st.methods, r.Method)
+	current := st.stored
+	st.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		if current == "" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"file not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w,
+			`{"name":"nginx.conf","sha256":"%s","size":1,"content_type":"raw"}`,
+			current,
+		)
+	case http.MethodPost:
+		if st.partial {
+			st.mu.Lock()
+			st.stored = "partial"
+			st.mu.Unlock()
+			_, _ = io.Copy(io.Discard, r.Body)
+			return
+		}
+
+		// A truncated body never reaches the store.
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, err := io.ReadAll(f)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		sum := fmt.Sprintf("%x", sha256.Sum256(data))
+		st.mu.Lock()
+		st.stored = sum
+		st.mu.Unlock()
+
+		if st.cancelOnCommit {
+			// The client gives up before the response arrives.
+			st.cancel()
+			<-r.Context().Done()
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w,
+			`{"name":"nginx.conf","sha256":"%s","size":%d,"changed":true,"content_type":"raw"}`,
+			sum, len(data),
+		)
+	case http.MethodDelete:
+		st.mu.Lock()
+		st.stored, st.deleted = "", true
+		st.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"name":"nginx.conf","deleted":true}`))
+	}
+}
+
+// changingReader returns different content after it is rewound,
+// simulating a file modified between hashing and streaming.
+type changingReader struct {
+	first   *bytes.Reader
+	second  *bytes.Reader
+	rewound bool
+}
+
+func (r *changingReader) Read(
+	p []byte,
+) (int, error) {
+	if r.rewound {
+		return r.second.Read(p)
+	}
+
+	return r.first.Read(p)
+}
+
+func (r *changingReader) Seek(
+	_ int64,
+	_ int,
+) (int64, error) {
+	if r.first.Len() == 0 {
+		r.rewound = true
+	}
+
+	return 0, nil
+}
+
 type errReader struct {
 	err error
 }
@@ -0,0 +1,415 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SyncOption configures a directory sync.
+type SyncOption func(*syncOptions)
+
+type syncOptions struct {
+	concurrency    int
+	dryRun         bool
+	deleteOrphans  bool
+	templateSuffix string
+}
+
+// WithSyncConcurrency limits how many uploads and deletes Sync issues
+// at once. Values below 1 use DefaultBulkConcurrency.
+func WithSyncConcurrency(
+	n int,
+) SyncOption {
+	return func(o *syncOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithSyncDryRun reports the files Sync would upload or delete without
+// changing anything.
+func WithSyncDryRun() SyncOption {
+	return func(o *syncOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithDeleteOrphans makes Sync delete objects under the prefix that
+// have no matching local file. Without it they are only reported. Sync
+// refuses it with an empty prefix, which would match every object.
+func WithDeleteOrphans() SyncOption {
+	return func(o *syncOptions) {
+		o.deleteOrphans = true
+	}
+}
+
+// WithTemplateSuffix makes Sync upload files whose name ends in suffix
+// (e.g. ".tmpl") with content type "template" instead of "raw". The
+// suffix is kept in the object name.
+func WithTemplateSuffix(
+	suffix string,
+) SyncOption {
+	return func(o *syncOptions) {
+		o.templateSuffix = suffix
+	}
+}
+
+// newSyncOptions applies opts over the defaults.
+func newSyncOptions(
+	opts []SyncOption,
+) syncOptions {
+	options := syncOptions{concurrency: DefaultBulkConcurrency}
+
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if options.concurrency < 1 {
+		options.concurrency = DefaultBulkConcurrency
+	}
+
+	return options
+}
+
+// SyncAction describes what Sync did with a file.
+type SyncAction string
+
+const (
+	// SyncUnchanged means the stored object already matched the file.
+	SyncUnchanged SyncAction = "unchanged"
+	// SyncUploaded means the file was new or modified and was uploaded.
+	SyncUploaded SyncAction = "uploaded"
+	// SyncDeleted means an orphaned object was deleted.
+	SyncDeleted SyncAction = "deleted"
+	// SyncOrphaned means an object has no local file and was kept.
+	SyncOrphaned SyncAction = "orphaned"
+)
+
+// SyncFile is the outcome of a sync for a single file or object.
+type SyncFile struct {
+	// Path is the file path relative to the synced directory, using
+	// forward slashes. Empty for orphaned objects.
+	Path string
+
+	// Name is the Object Store name.
+	Name string
+
+	// Action is what was done, or would be done in a dry run. When Err
+	// is set it is the action that failed.
+	Action SyncAction
+
+	// SHA256 is the local content hash, or the stored hash for
+	// orphaned objects.
+	SHA256 string
+
+	// Err is the error returned for this file, if any.
+	Err error
+}
+
+// SyncReport summarizes a directory sync.
+type SyncReport struct {
+	// Uploaded, Unchanged, Deleted, and Orphaned count the files by
+	// action, excluding failures.
+	Uploaded  int
+	Unchanged int
+	Deleted   int
+	Orphaned  int
+
+	// Failed is the number of files that returned an error.
+	Failed int
+
+	// DryRun is true when no changes were made.
+	DryRun bool
+
+	// Files holds the per-file outcome, local files in path order
+	// followed by orphaned objects in name order.
+	Files []SyncFile
+}
+
+// Err joins the per-file errors, or returns nil if every file synced.
+func (r *SyncReport) Err() error {
+	var errs []error
+
+	for _, f := range r.Files {
+		if f.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", f.Action, f.Name, f.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Sync mirrors the regular files under localDir into the Object Store.
+// Each file is stored as prefix joined with its relative path using
+// "/" separators, and is uploaded only when its SHA-256 differs from
+// the stored object, as with Changed; such uploads are forced so the
+// stored object is replaced. Objects under prefix without a
+// local file are reported, or deleted with WithDeleteOrphans; an empty
+// prefix makes every stored object a candidate, so deleting orphans
+// requires a prefix. Uploads and deletes run concurrently, limited by
+// WithSyncConcurrency. Per-file errors are recorded in the report; the
+// returned error is non-nil only if the options are invalid, or the
+// directory could not be walked or the stored objects listed.
+func (s *FileService) Sync(
+	ctx context.Context,
+	localDir string,
+	prefix string,
+	opts ...SyncOption,
+) (*SyncReport, error) {
+	options := newSyncOptions(opts)
+	prefix = strings.Trim(prefix, "/")
+
+	if options.deleteOrphans && prefix == "" {
+		return nil, fmt.Errorf("sync %s: deleting orphans requires a prefix", localDir)
+	}
+
+	paths, err := walkFiles(localDir)
+	if err != nil {
+		return nil, fmt.Errorf("sync %s: %w", localDir, err)
+	}
+
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sync %s: %w", localDir, err)
+	}
+
+	stored := make(map[string]FileItem, len(list.Data.Files))
+	for _, f := range list.Data.Files {
+		stored[f.Name] = f
+	}
+
+	report := &SyncReport{DryRun: options.dryRun}
+
+	local := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		name := syncName(prefix, p)
+		local[name] = true
+		report.Files = append(report.Files, SyncFile{Path: p, Name: name})
+	}
+
+	var orphans []string
+	for name := range stored {
+		if !local[name] && underPrefix(name, prefix) {
+			orphans = append(orphans, name)
+		}
+	}
+
+	sort.Strings(orphans)
+
+	for _, name := range orphans {
+		report.Files = append(report.Files, SyncFile{
+			Name:   name,
+			Action: SyncOrphaned,
+			SHA256: stored[name].SHA256,
+		})
+	}
+
+	sem := make(chan struct{}, options.concurrency)
+
+	var wg sync.WaitGroup
+
+	for i := range report.Files {
+		f := &report.Files[i]
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			f.Err = ctx.Err()
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if f.Path != "" {
+				s.syncFile(ctx, localDir, f, stored, options)
+			} else {
+				s.syncOrphan(ctx, f, options)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for _, f := range report.Files {
+		switch {
+		case f.Err != nil:
+			report.Failed++
+		case f.Action == SyncUploaded:
+			report.Uploaded++
+		case f.Action == SyncUnchanged:
+			report.Unchanged++
+		case f.Action == SyncDeleted:
+			report.Deleted++
+		case f.Action == SyncOrphaned:
+			report.Orphaned++
+		}
+	}
+
+	return report, nil
+}
+
+// syncFile hashes a local file and uploads it when it differs from the
+// stored object.
+func (s *FileService) syncFile(
+	ctx context.Context,
+	localDir string,
+	f *SyncFile,
+	stored map[string]FileItem,
+	options syncOptions,
+) {
+	f.Action = SyncUploaded
+
+	full := filepath.Join(localDir, filepath.FromSlash(f.Path))
+
+	sum, err := hashFile(full)
+	if err != nil {
+		f.Err = err
+
+		return
+	}
+
+	f.SHA256 = sum
+
+	if existing, ok := stored[f.Name]; ok && existing.SHA256 == sum {
+		f.Action = SyncUnchanged
+
+		return
+	}
+
+	if options.dryRun {
+		return
+	}
+
+	contentType := "raw"
+	if options.templateSuffix != "" && strings.HasSuffix(f.Path, options.templateSuffix) {
+		contentType = "template"
+	}
+
+	// The hashes were compared above, so force the upload: the API
+	// rejects replacing an object with different content otherwise.
+	if _, err := s.UploadFile(ctx, f.Name, contentType, full, WithForce()); err != nil {
+		f.Err = err
+	}
+}
+
+// syncOrphan deletes an orphaned object when WithDeleteOrphans is set.
+func (s *FileService) syncOrphan(
+	ctx context.Context,
+	f *SyncFile,
+	options syncOptions,
+) {
+	if !options.deleteOrphans {
+		return
+	}
+
+	f.Action = SyncDeleted
+
+	if options.dryRun {
+		return
+	}
+
+	if _, err := s.Delete(ctx, f.Name); err != nil {
+		f.Err = err
+	}
+}
+
+// walkFiles returns the regular files under dir as slash-separated
+// relative paths in lexical order.
+func walkFiles(
+	dir string,
+) ([]string, error) {
+	var paths []string
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+// hashFile returns the SHA-256 of the file at p.
+func hashFile(
+	p string,
+) (string, error) {
+	file, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("open file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	sum, _, err := hashSeeker(file)
+	if err != nil {
+		return "", fmt.Errorf("read file: %w", err)
+	}
+
+	return sum, nil
+}
+
+func syncName(
+	prefix string,
+	rel string,
+) string {
+	if prefix == "" {
+		return rel
+	}
+
+	return path.Join(prefix, rel)
+}
+
+func underPrefix(
+	name string,
+	prefix string,
+) bool {
+	return prefix == "" || strings.HasPrefix(name, prefix+"/")
+}
@@ -0,0 +1,352 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+// objectStore is an in-memory Object Store served over the file API.
+type objectStore struct {
+	mu      sync.Mutex
+	objects map[string]storedObject
+	fail    map[string]bool
+	calls   []string
+}
+
+type storedObject struct {
+	content     []byte
+	contentType string
+}
+
+func newObjectStore() *objectStore {
+	return &objectStore{
+		objects: make(map[string]storedObject),
+		fail:    make(map[string]bool),
+	}
+}
+
+func (o *objectStore) put(
+	name string,
+	content string,
+) {
+	o.objects[name] = storedObject{content: []byte(content), contentType: "raw"}
+}
+
+func (o *objectStore) metadata(
+	name string,
+	obj storedObject,
+) map[string]any {
+	return map[string]any{
+		"name":         name,
+		"sha256":       fmt.Sprintf("%x", sha256.Sum256(obj.content)),
+		"size":         len(obj.content),
+		"content_type": obj.contentType,
+	}
+}
+
+func (o *objectStore) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/file/")
+	if r.Method == http.MethodPost {
+		name = r.FormValue("name")
+	}
+
+	o.calls = append(o.calls, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	if o.fail[name] {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"storage failure"}`))
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/file":
+		names := make([]string, 0, len(o.objects))
+		for n := range o.objects {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		files := make([]map[string]any, 0, len(names))
+		for _, n := range names {
+			files = append(files, o.metadata(n, o.objects[n]))
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": files, "total": len(files)})
+	case r.Method == http.MethodPost:
+		f, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"file is required"}`))
+			return
+		}
+		content, _ := io.ReadAll(f)
+		obj := storedObject{content: content, contentType: r.FormValue("content_type")}
+
+		// Like the API, refuse to replace different content without force.
+		if existing, ok := o.objects[name]; ok &&
+			!bytes.Equal(existing.content, content) &&
+			r.URL.Query().Get("force") != "true" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"file already exists with different content"}`))
+			return
+		}
+
+		o.objects[name] = obj
+
+		resp := o.metadata(name, obj)
+		resp["changed"] = true
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(resp)
+	case r.Method == http.MethodGet:
+		obj, ok := o.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"file not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(o.metadata(name, obj))
+	case r.Method == http.MethodDelete:
+		delete(o.objects, name)
+		_ = json.NewEncoder(w).Encode(map[string]any{"name": name, "deleted": true})
+	}
+}
+
+type FileSyncPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	dir string
+}
+
+func (suite *FileSyncPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.dir = suite.T().TempDir()
+
+	for p, content := range map[string]string{
+		"nginx.conf":           "worker_processes 4;",
+		"conf.d/app.conf.tmpl": "listen {{ .Vars.port }};",
+		"conf.d/default.conf":  "listen 80;",
+	} {
+		full := filepath.Join(suite.dir, filepath.FromSlash(p))
+		suite.Require().NoError(os.MkdirAll(filepath.Dir(full), 0o750))
+		suite.Require().NoError(os.WriteFile(full, []byte(content), 0o600))
+	}
+}
+
+func (suite *FileSyncPublicTestSuite) TestSync() {
+	tests := []struct {
+		name         string
+		setup        func(*objectStore)
+		dir          string
+		prefix       string
+		opts         []osapi.SyncOption
+		validateFunc func(*osapi.SyncReport, error, *objectStore)
+	}{
+		{
+			name:   "when store is empty uploads every file",
+			prefix: "nginx",
+			opts:   []osapi.SyncOption{osapi.WithTemplateSuffix(".tmpl")},
+			validateFunc: func(report *osapi.SyncReport, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal(3, report.Uploaded)
+				suite.NoError(report.Err())
+
+				suite.Equal(
+					[]string{
+						"nginx/conf.d/app.conf.tmpl",
+						"nginx/conf.d/default.conf",
+						"nginx/nginx.conf",
+					},
+					[]string{report.Files[0].Name, report.Files[1].Name, report.Files[2].Name},
+				)
+				suite.Equal("conf.d/app.conf.tmpl", report.Files[0].Path)
+				suite.Equal("template", store.objects["nginx/conf.d/app.conf.tmpl"].contentType)
+				suite.Equal("raw", store.objects["nginx/nginx.conf"].contentType)
+			},
+		},
+		{
+			name:   "when some files are unchanged uploads only modified files",
+			prefix: "nginx/",
+			setup: func(store *objectStore) {
+				store.put("nginx/nginx.conf", "worker_processes 4;")
+				store.put("nginx/conf.d/default.conf", "listen 8080;")
+			},
+			validateFunc: func(report *osapi.SyncReport, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal(2, report.Uploaded)
+				suite.Equal(1, report.Unchanged)
+				suite.Zero(report.Failed)
+				suite.Equal(osapi.SyncUnchanged, report.Files[2].Action)
+				suite.Equal("listen 80;", string(store.objects["nginx/conf.d/default.conf"].content))
+
+				posts := 0
+				for _, call := range store.calls {
+					if call == "POST /file" {
+						posts++
+					}
+				}
+				suite.Equal(2, posts)
+			},
+		},
+		{
+			name:   "when orphans exist reports them without deleting",
+			prefix: "nginx",
+			setup: func(store *objectStore) {
+				store.put("nginx/old.conf", "stale")
+				store.put("other/keep.conf", "keep")
+			},
+			validateFunc: func(report *osapi.SyncReport, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal(1, report.Orphaned)
+				suite.Equal("nginx/old.conf", report.Files[3].Name)
+				suite.Empty(report.Files[3].Path)
+				suite.Contains(store.objects, "nginx/old.conf")
+			},
+		},
+		{
+			name:   "when deleting orphans removes them under the prefix",
+			prefix: "nginx",
+			opts:   []osapi.SyncOption{osapi.WithDeleteOrphans(), osapi.WithSyncConcurrency(1)},
+			setup: func(store *objectStore) {
+				store.put("nginx/old.conf", "stale")
+				store.put("nginxy/keep.conf", "keep")
+			},
+			validateFunc: func(report *osapi.SyncReport, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal(1, report.Deleted)
+				suite.Equal(osapi.SyncDeleted, report.Files[3].Action)
+				suite.NotContains(store.objects, "nginx/old.conf")
+				suite.Contains(store.objects, "nginxy/keep.conf")
+			},
+		},
+		{
+			name:   "when dry run reports changes without making them",
+			prefix: "nginx",
+			opts:   []osapi.SyncOption{osapi.WithSyncDryRun(), osapi.WithDeleteOrphans()},
+			setup: func(store *objectStore) {
+				store.put("nginx/old.conf", "stale")
+			},
+			validateFunc: func(report *osapi.SyncReport, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.True(report.DryRun)
+				suite.Equal(3, report.Uploaded)
+				suite.Equal(1, report.Deleted)
+				suite.Len(store.objects, 1)
+			},
+		},
+		{
+			name:   "when deleting orphans without a prefix refuses to sync",
+			prefix: "/",
+			opts:   []osapi.SyncOption{osapi.WithDeleteOrphans()},
+			setup: func(store *objectStore) {
+				store.put("other/keep.conf", "keep")
+			},
+			validateFunc: func(report *osapi.SyncReport, err error, store *objectStore) {
+				suite.Nil(report)
+				suite.ErrorContains(err, "deleting orphans requires a prefix")
+				suite.Contains(store.objects, "other/keep.conf")
+				suite.Empty(store.calls)
+			},
+		},
+		{
+			name: "when an upload fails records the error",
+			setup: func(store *objectStore) {
+				store.fail["nginx.conf"] = true
+			},
+			validateFunc: func(report *osapi.SyncReport, err error, _ *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal(2, report.Uploaded)
+				suite.Equal(1, report.Failed)
+				suite.ErrorContains(report.Err(), "uploaded nginx.conf")
+			},
+		},
+		{
+			name: "when listing fails returns error",
+			setup: func(store *objectStore) {
+				store.fail["/file"] = true
+			},
+			validateFunc: func(report *osapi.SyncReport, err error, _ *objectStore) {
+				suite.Nil(report)
+				suite.ErrorContains(err, "sync")
+			},
+		},
+		{
+			name: "when directory is missing returns error",
+			dir:  "missing",
+			validateFunc: func(report *osapi.SyncReport, err error, store *objectStore) {
+				suite.Nil(report)
+				suite.ErrorContains(err, "sync")
+				suite.Empty(store.calls)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			store := newObjectStore()
+			if tc.setup != nil {
+				tc.setup(store)
+			}
+
+			server := httptest.NewServer(store)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token", osapi.WithLogger(slog.Default()))
+
+			dir := suite.dir
+			if tc.dir != "" {
+				dir = filepath.Join(dir, tc.dir)
+			}
+
+			report, err := sut.File.Sync(suite.ctx, dir, tc.prefix, tc.opts...)
+			tc.validateFunc(report, err, store)
+		})
+	}
+}
+
+func TestFileSyncPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(FileSyncPublicTestSuite))
+}
@@ -0,0 +1,326 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// aliasTag marks the object that records which version of a name is
+// current: "<name>@current@<sha>".
+const aliasTag = "current"
+
+// FileVersion is a stored revision of a versioned file.
+type FileVersion struct {
+	// Name is the Object Store name of the revision, "<name>@<sha>".
+	Name string
+
+	// Version is the SHA-256 of the revision's content.
+	Version string
+
+	Size        int
+	ContentType string
+
+	// Current is true when the alias points at this revision.
+	Current bool
+}
+
+// FileHistory lists the stored revisions of a versioned file.
+type FileHistory struct {
+	// Name is the unversioned file name.
+	Name string
+
+	// Current is the version the alias points at, or empty when no
+	// alias exists.
+	Current string
+
+	// Versions holds the stored revisions in version order. The Object
+	// Store records no upload times, so revisions cannot be ordered by
+	// age.
+	Versions []FileVersion
+
+	// aliases holds the Object Store names of the alias markers.
+	aliases []string
+}
+
+// VersionName returns the Object Store name of a revision of name.
+// Deploy this name to pin a host to that revision.
+func VersionName(
+	name string,
+	version string,
+) string {
+	return name + "@" + version
+}
+
+// UploadVersion stores file as a new revision of name under
+// VersionName(name, sha), where sha is the SHA-256 of the content, and
+// moves the alias to it. Uploading content that already has a revision
+// only moves the alias. opts apply to the revision upload.
+func (s *FileService) UploadVersion(
+	ctx context.Context,
+	name string,
+	contentType string,
+	file io.ReadSeeker,
+	opts ...UploadOption,
+) (*FileVersion, error) {
+	sum, _, err := hashSeeker(file)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	history, err := s.history(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Upload(ctx, VersionName(name, sum), contentType, file, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.setAlias(ctx, history, sum); err != nil {
+		return nil, err
+	}
+
+	return &FileVersion{
+		Name:        resp.Data.Name,
+		Version:     sum,
+		Size:        resp.Data.Size,
+		ContentType: contentType,
+		Current:     true,
+	}, nil
+}
+
+// History lists the stored revisions of name and the version its alias
+// points at. It returns a *NotFoundError when name has no revisions.
+func (s *FileService) History(
+	ctx context.Context,
+	name string,
+) (*FileHistory, error) {
+	history, err := s.history(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history.Versions) == 0 && len(history.aliases) == 0 {
+		return nil, &NotFoundError{APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("no versions of %s", name),
+		}}
+	}
+
+	if len(history.aliases) > 1 {
+		return nil, fmt.Errorf(
+			"file history %s: alias points at %d versions",
+			name,
+			len(history.aliases),
+		)
+	}
+
+	return history, nil
+}
+
+// Resolve returns the Object Store name of the revision the alias of
+// name points at, for use as the object name of a deploy.
+func (s *FileService) Resolve(
+	ctx context.Context,
+	name string,
+) (string, error) {
+	history, err := s.History(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	if history.Current == "" {
+		return "", &NotFoundError{APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("%s has no current version", name),
+		}}
+	}
+
+	return VersionName(name, history.Current), nil
+}
+
+// Rollback moves the alias of name to a stored revision. version is a
+// SHA-256, a unique prefix of one, or a name returned by VersionName.
+// Content is not copied: hosts pick up the revision the next time the
+// name returned by Resolve is deployed.
+func (s *FileService) Rollback(
+	ctx context.Context,
+	name string,
+	version string,
+) (*FileVersion, error) {
+	history, err := s.history(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	version = strings.TrimPrefix(version, name+"@")
+
+	var matched []int
+	for i, v := range history.Versions {
+		if version != "" && strings.HasPrefix(v.Version, version) {
+			matched = append(matched, i)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, &NotFoundError{APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("version %s of %s not found", version, name),
+		}}
+	case 1:
+	default:
+		return nil, fmt.Errorf(
+			"rollback %s: version %s matches %d versions",
+			name,
+			version,
+			len(matched),
+		)
+	}
+
+	target := history.Versions[matched[0]]
+	if err := s.setAlias(ctx, history, target.Version); err != nil {
+		return nil, err
+	}
+
+	target.Current = true
+
+	return &target, nil
+}
+
+// history lists the revisions and alias markers of name.
+func (s *FileService) history(
+	ctx context.Context,
+	name string,
+) (*FileHistory, error) {
+	resp, err := s.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("file history %s: %w", name, err)
+	}
+
+	history := &FileHistory{Name: name}
+
+	for _, f := range resp.Data.Files {
+		version, alias, ok := parseVersionName(name, f.Name)
+		switch {
+		case !ok:
+		case alias:
+			history.Current = version
+			history.aliases = append(history.aliases, f.Name)
+		default:
+			history.Versions = append(history.Versions, FileVersion{
+				Name:        f.Name,
+				Version:     version,
+				Size:        f.Size,
+				ContentType: f.ContentType,
+			})
+		}
+	}
+
+	sort.Slice(history.Versions, func(i, j int) bool {
+		return history.Versions[i].Version < history.Versions[j].Version
+	})
+
+	for i := range history.Versions {
+		history.Versions[i].Current = history.Versions[i].Version == history.Current
+	}
+
+	return history, nil
+}
+
+// setAlias points the alias of history.Name at version. The new marker
+// is written before the old ones are deleted so the alias is never
+// missing.
+func (s *FileService) setAlias(
+	ctx context.Context,
+	history *FileHistory,
+	version string,
+) error {
+	marker := VersionName(history.Name, aliasTag+"@"+version)
+
+	if _, err := s.Upload(
+		ctx,
+		marker,
+		"raw",
+		strings.NewReader(version+"\n"),
+	); err != nil {
+		return fmt.Errorf("set alias %s: %w", history.Name, err)
+	}
+
+	var errs []error
+	for _, stale := range history.aliases {
+		if stale == marker {
+			continue
+		}
+
+		if _, err := s.Delete(ctx, stale); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("set alias %s: %w", history.Name, err)
+	}
+
+	history.Current = version
+	history.aliases = []string{marker}
+
+	return nil
+}
+
+// parseVersionName reports whether object is a revision or an alias
+// marker of name, and the version it names.
+func parseVersionName(
+	name string,
+	object string,
+) (version string, alias bool, ok bool) {
+	rest, found := strings.CutPrefix(object, name+"@")
+	if !found {
+		return "", false, false
+	}
+
+	if v, found := strings.CutPrefix(rest, aliasTag+"@"); found && isSHA256(v) {
+		return v, true, true
+	}
+
+	return rest, false, isSHA256(rest)
+}
+
+// isSHA256 reports whether s is a hex-encoded SHA-256.
+func isSHA256(
+	s string,
+) bool {
+	if len(s) != 64 {
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+
+	return err == nil
+}
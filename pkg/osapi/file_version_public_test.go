@@ -0,0 +1,379 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type FileVersionPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	v1  string
+	v2  string
+}
+
+func (suite *FileVersionPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+	suite.v1 = fmt.Sprintf("%x", sha256.Sum256([]byte("worker_processes 2;")))
+	suite.v2 = fmt.Sprintf("%x", sha256.Sum256([]byte("worker_processes 4;")))
+}
+
+// seed stores both revisions of nginx.conf with the alias on v2.
+func (suite *FileVersionPublicTestSuite) seed(
+	store *objectStore,
+) {
+	store.put("nginx.conf@"+suite.v1, "worker_processes 2;")
+	store.put("nginx.conf@"+suite.v2, "worker_processes 4;")
+	store.put("nginx.conf@current@"+suite.v2, suite.v2+"\n")
+	store.put("nginx.conf", "unversioned")
+	store.put("nginx.conf.bak@"+suite.v1, "worker_processes 2;")
+}
+
+func (suite *FileVersionPublicTestSuite) objects(
+	store *objectStore,
+) []string {
+	names := make([]string, 0, len(store.objects))
+	for n := range store.objects {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (suite *FileVersionPublicTestSuite) TestUploadVersion() {
+	tests := []struct {
+		name         string
+		content      string
+		setup        func(*objectStore)
+		validateFunc func(*osapi.FileVersion, error, *objectStore)
+	}{
+		{
+			name:    "when first version stores revision and alias",
+			content: "worker_processes 2;",
+			validateFunc: func(v *osapi.FileVersion, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal("nginx.conf@"+suite.v1, v.Name)
+				suite.Equal(suite.v1, v.Version)
+				suite.Equal("raw", v.ContentType)
+				suite.True(v.Current)
+				suite.Equal([]string{
+					"nginx.conf@current@" + suite.v1,
+					"nginx.conf@" + suite.v1,
+				}, suite.objects(store))
+			},
+		},
+		{
+			name:    "when existing version re-uploaded only moves alias",
+			content: "worker_processes 2;",
+			setup:   suite.seed,
+			validateFunc: func(v *osapi.FileVersion, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal(suite.v1, v.Version)
+				suite.Contains(suite.objects(store), "nginx.conf@current@"+suite.v1)
+				suite.NotContains(suite.objects(store), "nginx.conf@current@"+suite.v2)
+				suite.Contains(suite.objects(store), "nginx.conf@"+suite.v2)
+			},
+		},
+		{
+			name:    "when alias cannot be written returns error",
+			content: "worker_processes 2;",
+			setup: func(store *objectStore) {
+				store.fail["nginx.conf@current@"+suite.v1] = true
+			},
+			validateFunc: func(v *osapi.FileVersion, err error, store *objectStore) {
+				suite.Nil(v)
+				suite.ErrorContains(err, "set alias nginx.conf")
+				suite.Contains(suite.objects(store), "nginx.conf@"+suite.v1)
+			},
+		},
+		{
+			name:    "when revision upload fails leaves alias unchanged",
+			content: "worker_processes 2;",
+			setup: func(store *objectStore) {
+				suite.seed(store)
+				delete(store.objects, "nginx.conf@"+suite.v1)
+				store.fail["nginx.conf@"+suite.v1] = true
+			},
+			validateFunc: func(v *osapi.FileVersion, err error, store *objectStore) {
+				suite.Nil(v)
+				suite.Error(err)
+				suite.Contains(suite.objects(store), "nginx.conf@current@"+suite.v2)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			store := newObjectStore()
+			if tc.setup != nil {
+				tc.setup(store)
+			}
+
+			server := httptest.NewServer(store)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token", osapi.WithLogger(slog.Default()))
+
+			v, err := sut.File.UploadVersion(
+				suite.ctx,
+				"nginx.conf",
+				"raw",
+				strings.NewReader(tc.content),
+			)
+			tc.validateFunc(v, err, store)
+		})
+	}
+}
+
+func (suite *FileVersionPublicTestSuite) TestHistory() {
+	tests := []struct {
+		name         string
+		setup        func(*objectStore)
+		validateFunc func(*osapi.FileHistory, error)
+	}{
+		{
+			name:  "when versions exist lists them with current marked",
+			setup: suite.seed,
+			validateFunc: func(h *osapi.FileHistory, err error) {
+				suite.Require().NoError(err)
+				suite.Equal("nginx.conf", h.Name)
+				suite.Equal(suite.v2, h.Current)
+				suite.Require().Len(h.Versions, 2)
+
+				current := 0
+				for _, v := range h.Versions {
+					suite.True(strings.HasPrefix(v.Name, "nginx.conf@"))
+					if v.Current {
+						current++
+						suite.Equal(suite.v2, v.Version)
+					}
+				}
+				suite.Equal(1, current)
+				suite.Less(h.Versions[0].Version, h.Versions[1].Version)
+			},
+		},
+		{
+			name: "when no versions returns not found",
+			setup: func(store *objectStore) {
+				store.put("nginx.conf", "unversioned")
+			},
+			validateFunc: func(h *osapi.FileHistory, err error) {
+				suite.Nil(h)
+
+				var notFound *osapi.NotFoundError
+				suite.ErrorAs(err, &notFound)
+			},
+		},
+		{
+			name: "when alias points at several versions returns error",
+			setup: func(store *objectStore) {
+				suite.seed(store)
+				store.put("nginx.conf@current@"+suite.v1, suite.v1+"\n")
+			},
+			validateFunc: func(h *osapi.FileHistory, err error) {
+				suite.Nil(h)
+				suite.ErrorContains(err, "alias points at 2 versions")
+			},
+		},
+		{
+			name: "when list fails returns error",
+			setup: func(store *objectStore) {
+				store.fail["/file"] = true
+			},
+			validateFunc: func(h *osapi.FileHistory, err error) {
+				suite.Nil(h)
+				suite.ErrorContains(err, "file history nginx.conf")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			store := newObjectStore()
+			tc.setup(store)
+
+			server := httptest.NewServer(store)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token", osapi.WithLogger(slog.Default()))
+
+			h, err := sut.File.History(suite.ctx, "nginx.conf")
+			tc.validateFunc(h, err)
+		})
+	}
+}
+
+func (suite *FileVersionPublicTestSuite) TestResolve() {
+	tests := []struct {
+		name         string
+		setup        func(*objectStore)
+		validateFunc func(string, error)
+	}{
+		{
+			name:  "when alias exists returns current revision name",
+			setup: suite.seed,
+			validateFunc: func(name string, err error) {
+				suite.Require().NoError(err)
+				suite.Equal(osapi.VersionName("nginx.conf", suite.v2), name)
+			},
+		},
+		{
+			name: "when no alias returns not found",
+			setup: func(store *objectStore) {
+				store.put("nginx.conf@"+suite.v1, "worker_processes 2;")
+			},
+			validateFunc: func(name string, err error) {
+				suite.Empty(name)
+
+				var notFound *osapi.NotFoundError
+				suite.ErrorAs(err, &notFound)
+				suite.ErrorContains(err, "no current version")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			store := newObjectStore()
+			tc.setup(store)
+
+			server := httptest.NewServer(store)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token", osapi.WithLogger(slog.Default()))
+
+			name, err := sut.File.Resolve(suite.ctx, "nginx.conf")
+			tc.validateFunc(name, err)
+		})
+	}
+}
+
+func (suite *FileVersionPublicTestSuite) TestRollback() {
+	tests := []struct {
+		name         string
+		version      func() string
+		setup        func(*objectStore)
+		validateFunc func(*osapi.FileVersion, error, *objectStore)
+	}{
+		{
+			name:    "when version is a sha moves alias",
+			version: func() string { return suite.v1 },
+			setup:   suite.seed,
+			validateFunc: func(v *osapi.FileVersion, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal("nginx.conf@"+suite.v1, v.Name)
+				suite.True(v.Current)
+				suite.Contains(suite.objects(store), "nginx.conf@current@"+suite.v1)
+				suite.NotContains(suite.objects(store), "nginx.conf@current@"+suite.v2)
+				suite.Contains(suite.objects(store), "nginx.conf@"+suite.v2)
+			},
+		},
+		{
+			name:    "when version is a prefix or versioned name resolves it",
+			version: func() string { return "nginx.conf@" + suite.v1[:8] },
+			setup:   suite.seed,
+			validateFunc: func(v *osapi.FileVersion, err error, _ *objectStore) {
+				suite.Require().NoError(err)
+				suite.Equal(suite.v1, v.Version)
+			},
+		},
+		{
+			name:    "when alias points at several versions repairs it",
+			version: func() string { return suite.v2 },
+			setup: func(store *objectStore) {
+				suite.seed(store)
+				store.put("nginx.conf@current@"+suite.v1, suite.v1+"\n")
+			},
+			validateFunc: func(_ *osapi.FileVersion, err error, store *objectStore) {
+				suite.Require().NoError(err)
+				suite.Contains(suite.objects(store), "nginx.conf@current@"+suite.v2)
+				suite.NotContains(suite.objects(store), "nginx.conf@current@"+suite.v1)
+			},
+		},
+		{
+			name:    "when version does not exist returns not found",
+			version: func() string { return "ffff" },
+			setup:   suite.seed,
+			validateFunc: func(v *osapi.FileVersion, err error, store *objectStore) {
+				suite.Nil(v)
+
+				var notFound *osapi.NotFoundError
+				suite.ErrorAs(err, &notFound)
+				suite.Contains(suite.objects(store), "nginx.conf@current@"+suite.v2)
+			},
+		},
+		{
+			name:    "when version is empty returns not found",
+			version: func() string { return "" },
+			setup:   suite.seed,
+			validateFunc: func(v *osapi.FileVersion, err error, _ *objectStore) {
+				suite.Nil(v)
+
+				var notFound *osapi.NotFoundError
+				suite.ErrorAs(err, &notFound)
+			},
+		},
+		{
+			name:    "when old alias cannot be deleted returns error",
+			version: func() string { return suite.v1 },
+			setup: func(store *objectStore) {
+				suite.seed(store)
+				store.fail["nginx.conf@current@"+suite.v2] = true
+			},
+			validateFunc: func(v *osapi.FileVersion, err error, _ *objectStore) {
+				suite.Nil(v)
+				suite.ErrorContains(err, "set alias nginx.conf")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			store := newObjectStore()
+			tc.setup(store)
+
+			server := httptest.NewServer(store)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token", osapi.WithLogger(slog.Default()))
+
+			v, err := sut.File.Rollback(suite.ctx, "nginx.conf", tc.version())
+			tc.validateFunc(v, err, store)
+		})
+	}
+}
+
+func TestFileVersionPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(FileVersionPublicTestSuite))
+}
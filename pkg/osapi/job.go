@@ -23,6 +23,8 @@ package osapi
 import (
 	"context"
 	"fmt"
+	"iter"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -32,9 +34,13 @@ import (
 // JobService provides job queue operations.
 type JobService struct {
 	client *gen.ClientWithResponses
+	agents *AgentService
+	cache  *responseCache
 }
 
-// Create creates a new job with the given operation and target.
+// Create creates a new job with the given operation and target. Cached
+// responses for the target are invalidated, since the job may change
+// what they report.
 func (s *JobService) Create(
 	ctx context.Context,
 	operation map[string]interface{},
@@ -45,6 +51,8 @@ func (s *JobService) Create(
 		TargetHostname: target,
 	}
 
+	defer s.cache.invalidate(target)
+
 	resp, err := s.client.PostJobWithResponse(ctx, body)
 	if err != nil {
 		return nil, fmt.Errorf("create job: %w", err)
@@ -64,6 +72,29 @@ func (s *JobService) Create(
 	return NewResponse(jobCreatedFromGen(resp.JSON201), resp.Body), nil
 }
 
+// Submit validates a typed job operation and creates a job for it on
+// the given target. Invalid operations are rejected before any request
+// is sent.
+func (s *JobService) Submit(
+	ctx context.Context,
+	op JobOperation,
+	target string,
+) (*Response[JobCreated], error) {
+	if op == nil {
+		return nil, fmt.Errorf("submit job: operation is required")
+	}
+
+	if target == "" {
+		return nil, fmt.Errorf("submit job: target is required")
+	}
+
+	if err := op.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s operation: %w", op.Type(), err)
+	}
+
+	return s.Create(ctx, operationBody(op), target)
+}
+
 // Get retrieves a job by ID.
 func (s *JobService) Get(
 	ctx context.Context,
@@ -115,7 +146,10 @@ func (s *JobService) Delete(
 	return nil
 }
 
-// ListParams contains optional filters for listing jobs.
+// ListParams contains optional filters for listing jobs. Status,
+// Limit, and Offset are applied by the server. The remaining filters
+// are not supported by the API and are applied client-side, which
+// requires scanning every job with the given status.
 type ListParams struct {
 	// Status filters by job status (e.g., "pending", "completed").
 	Status string
@@ -126,12 +160,97 @@ type ListParams struct {
 	// Offset is the number of results to skip. Zero starts from the
 	// beginning.
 	Offset int
+
+	// Hostname filters by the agent that processed the job, or any
+	// agent reporting state on a broadcast job.
+	Hostname string
+
+	// Target filters by routing target. Jobs do not record their
+	// target, so a label selector such as "group:web" is resolved to
+	// the hostnames of the agents it matches now, and jobs on any of
+	// them are kept. A hostname matches like Hostname; "_all" and
+	// "_any" match every job.
+	Target string
+
+	// OperationType filters by job type (e.g., "file.deploy.execute").
+	OperationType string
+
+	// CreatedAfter keeps jobs created at or after this time.
+	CreatedAfter time.Time
+
+	// CreatedBefore keeps jobs created before this time.
+	CreatedBefore time.Time
+
+	// Sort orders jobs by creation time. Empty keeps the server order.
+	Sort SortOrder
 }
 
-// List retrieves jobs, optionally filtered by status.
+// List retrieves jobs matching params. When only server-side filters
+// are set, a single page is requested; otherwise every job with the
+// given status is fetched, filtered, sorted, and then windowed by
+// Offset and Limit, and TotalItems counts the filtered jobs.
 func (s *JobService) List(
 	ctx context.Context,
 	params ListParams,
+) (*Response[JobList], error) {
+	if !params.clientSide() {
+		return s.list(ctx, params)
+	}
+
+	hosts, err := s.resolveTarget(ctx, params.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		items  []JobDetail
+		counts map[string]int
+	)
+
+	fetch := func(
+		ctx context.Context,
+		limit int,
+		offset int,
+	) ([]JobDetail, int, error) {
+		resp, err := s.list(ctx, ListParams{
+			Status: params.Status,
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		counts = resp.Data.StatusCounts
+
+		return resp.Data.Items, resp.Data.TotalItems, nil
+	}
+
+	for job, err := range paginate(ctx, fetch, 0, 0) {
+		if err != nil {
+			return nil, err
+		}
+
+		if params.matches(job, hosts) {
+			items = append(items, job)
+		}
+	}
+
+	sortJobs(items, params.Sort)
+
+	list := JobList{
+		Items:        windowJobs(items, params.Offset, params.Limit),
+		TotalItems:   len(items),
+		StatusCounts: counts,
+	}
+
+	return NewResponse(list, nil), nil
+}
+
+// list requests a single page using the server-side filters only.
+func (s *JobService) list(
+	ctx context.Context,
+	params ListParams,
 ) (*Response[JobList], error) {
 	p := &gen.GetJobParams{}
 
@@ -167,6 +286,93 @@ func (s *JobService) List(
 	return NewResponse(jobListFromGen(resp.JSON200), resp.Body), nil
 }
 
+// All returns an iterator over every job matching filter, fetching
+// pages transparently. filter.Offset sets the starting position and a
+// non-zero filter.Limit caps the total number of jobs yielded. The
+// iteration stops at the first error, which is yielded with a zero
+// JobDetail. Client-side filters are applied as pages arrive; a Sort
+// order buffers every matching job before the first is yielded.
+func (s *JobService) All(
+	ctx context.Context,
+	filter ListParams,
+	opts ...PageOption,
+) iter.Seq2[JobDetail, error] {
+	fetch := func(
+		ctx context.Context,
+		limit int,
+		offset int,
+	) ([]JobDetail, int, error) {
+		resp, err := s.list(ctx, ListParams{
+			Status: filter.Status,
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return resp.Data.Items, resp.Data.TotalItems, nil
+	}
+
+	if !filter.clientSide() {
+		return paginate(ctx, fetch, filter.Offset, filter.Limit, opts...)
+	}
+
+	return func(yield func(JobDetail, error) bool) {
+		hosts, err := s.resolveTarget(ctx, filter.Target)
+		if err != nil {
+			yield(JobDetail{}, err)
+
+			return
+		}
+
+		var matched []JobDetail
+
+		skipped, yielded := 0, 0
+
+		for job, err := range paginate(ctx, fetch, 0, 0, opts...) {
+			if err != nil {
+				yield(JobDetail{}, err)
+
+				return
+			}
+
+			if !filter.matches(job, hosts) {
+				continue
+			}
+
+			if filter.Sort != "" {
+				matched = append(matched, job)
+
+				continue
+			}
+
+			if skipped < filter.Offset {
+				skipped++
+
+				continue
+			}
+
+			if !yield(job, nil) {
+				return
+			}
+
+			yielded++
+			if filter.Limit > 0 && yielded >= filter.Limit {
+				return
+			}
+		}
+
+		sortJobs(matched, filter.Sort)
+
+		for _, job := range windowJobs(matched, filter.Offset, filter.Limit) {
+			if !yield(job, nil) {
+				return
+			}
+		}
+	}
+}
+
 // QueueStats retrieves job queue statistics.
 func (s *JobService) QueueStats(
 	ctx context.Context,
@@ -191,6 +397,8 @@ func (s *JobService) QueueStats(
 }
 
 // Retry retries a failed job by ID, optionally on a different target.
+// Cached responses for the target are invalidated; without a target
+// the original one is unknown, so the whole cache is cleared.
 func (s *JobService) Retry(
 	ctx context.Context,
 	id string,
@@ -206,6 +414,8 @@ func (s *JobService) Retry(
 		body.TargetHostname = &target
 	}
 
+	defer s.cache.invalidate(target)
+
 	resp, err := s.client.RetryJobByIDWithResponse(ctx, parsedID, body)
 	if err != nil {
 		return nil, fmt.Errorf("retry job: %w", err)
@@ -0,0 +1,310 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultBulkConcurrency is the number of concurrent requests bulk
+// helpers issue when WithConcurrency is not set.
+const DefaultBulkConcurrency = 4
+
+// BulkOption configures a bulk job operation.
+type BulkOption func(*bulkOptions)
+
+type bulkOptions struct {
+	concurrency int
+	target      string
+	dryRun      bool
+}
+
+// WithConcurrency limits how many requests a bulk operation issues at
+// once. Values below 1 use DefaultBulkConcurrency.
+func WithConcurrency(
+	n int,
+) BulkOption {
+	return func(o *bulkOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithRetryTarget retargets retried jobs to the given hostname or
+// routing target instead of their original one.
+func WithRetryTarget(
+	target string,
+) BulkOption {
+	return func(o *bulkOptions) {
+		o.target = target
+	}
+}
+
+// WithDryRun reports the jobs a bulk operation would act on without
+// retrying or deleting anything.
+func WithDryRun() BulkOption {
+	return func(o *bulkOptions) {
+		o.dryRun = true
+	}
+}
+
+// BulkItem is the outcome of a bulk operation for a single job.
+type BulkItem struct {
+	// JobID is the job that was acted on.
+	JobID string
+
+	// NewJobID is the job created by a retry. Empty for deletes.
+	NewJobID string
+
+	// Err is the error returned for this job, if any.
+	Err error
+}
+
+// BulkResult summarizes a bulk job operation.
+type BulkResult struct {
+	// Matched is the number of jobs selected by the filter.
+	Matched int
+
+	// Succeeded is the number of jobs acted on without error.
+	Succeeded int
+
+	// Failed is the number of jobs that returned an error.
+	Failed int
+
+	// DryRun is true when no changes were made.
+	DryRun bool
+
+	// Items holds the per-job outcome in selection order.
+	Items []BulkItem
+}
+
+// Err joins the per-job errors, or returns nil if every job succeeded.
+func (r *BulkResult) Err() error {
+	var errs []error
+
+	for _, item := range r.Items {
+		if item.Err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", item.JobID, item.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeadLetterReport describes the dead-letter queue. The API exposes
+// only the DLQ size, not its entries, so the failed jobs are listed
+// separately; they are not the DLQ entries and may include jobs that
+// were already retried.
+type DeadLetterReport struct {
+	// Count is the number of messages in the dead-letter queue.
+	Count int
+
+	// FailedJobs are all jobs with status failed.
+	FailedJobs []JobDetail
+}
+
+// RetryFailed retries every failed job matching filter. The filter
+// status is forced to "failed"; WithRetryTarget retargets the retried
+// jobs. Per-job errors are recorded in the result; the returned error
+// is non-nil only if the jobs could not be listed.
+func (s *JobService) RetryFailed(
+	ctx context.Context,
+	filter ListParams,
+	opts ...BulkOption,
+) (*BulkResult, error) {
+	filter.Status = JobStatusFailed
+
+	ids, err := s.collectIDs(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("retry failed jobs: %w", err)
+	}
+
+	return s.retryIDs(ctx, ids, newBulkOptions(opts)), nil
+}
+
+// PurgeCompleted deletes completed jobs created more than olderThan
+// ago. Jobs whose creation time cannot be parsed are left in place.
+func (s *JobService) PurgeCompleted(
+	ctx context.Context,
+	olderThan time.Duration,
+	opts ...BulkOption,
+) (*BulkResult, error) {
+	ids, err := s.collectIDs(ctx, ListParams{
+		Status:        JobStatusCompleted,
+		CreatedBefore: time.Now().Add(-olderThan),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("purge completed jobs: %w", err)
+	}
+
+	return s.DeleteBatch(ctx, ids, opts...), nil
+}
+
+// DeleteBatch deletes the given jobs concurrently.
+func (s *JobService) DeleteBatch(
+	ctx context.Context,
+	ids []string,
+	opts ...BulkOption,
+) *BulkResult {
+	return runBulk(ctx, ids, newBulkOptions(opts), func(id string) BulkItem {
+		err := s.Delete(ctx, id)
+
+		return BulkItem{JobID: id, Err: err}
+	})
+}
+
+// DeadLetters reports the dead-letter queue size alongside every
+// failed job. To requeue work, pick jobs with RetryFailed and an
+// explicit filter; the DLQ entries themselves cannot be listed.
+func (s *JobService) DeadLetters(
+	ctx context.Context,
+) (*Response[DeadLetterReport], error) {
+	stats, err := s.QueueStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := DeadLetterReport{Count: stats.Data.DlqCount}
+
+	for job, err := range s.All(ctx, ListParams{Status: JobStatusFailed}) {
+		if err != nil {
+			return nil, fmt.Errorf("list failed jobs: %w", err)
+		}
+
+		report.FailedJobs = append(report.FailedJobs, job)
+	}
+
+	return NewResponse(report, nil), nil
+}
+
+// collectIDs returns the IDs of all jobs matching filter.
+func (s *JobService) collectIDs(
+	ctx context.Context,
+	filter ListParams,
+) ([]string, error) {
+	var ids []string
+
+	for job, err := range s.All(ctx, filter) {
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, job.ID)
+	}
+
+	return ids, nil
+}
+
+// retryIDs retries the given jobs concurrently.
+func (s *JobService) retryIDs(
+	ctx context.Context,
+	ids []string,
+	options bulkOptions,
+) *BulkResult {
+	return runBulk(ctx, ids, options, func(id string) BulkItem {
+		resp, err := s.Retry(ctx, id, options.target)
+		if err != nil {
+			return BulkItem{JobID: id, Err: err}
+		}
+
+		return BulkItem{JobID: id, NewJobID: resp.Data.JobID}
+	})
+}
+
+// newBulkOptions applies opts over the defaults.
+func newBulkOptions(
+	opts []BulkOption,
+) bulkOptions {
+	options := bulkOptions{concurrency: DefaultBulkConcurrency}
+
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if options.concurrency < 1 {
+		options.concurrency = DefaultBulkConcurrency
+	}
+
+	return options
+}
+
+// runBulk calls fn for each ID with at most options.concurrency calls
+// in flight. IDs not started before ctx is done record ctx.Err().
+func runBulk(
+	ctx context.Context,
+	ids []string,
+	options bulkOptions,
+	fn func(id string) BulkItem,
+) *BulkResult {
+	result := &BulkResult{
+		Matched: len(ids),
+		DryRun:  options.dryRun,
+		Items:   make([]BulkItem, len(ids)),
+	}
+
+	if options.dryRun {
+		for i, id := range ids {
+			result.Items[i] = BulkItem{JobID: id}
+		}
+
+		return result
+	}
+
+	sem := make(chan struct{}, options.concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			result.Items[i] = BulkItem{JobID: id, Err: ctx.Err()}
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			result.Items[i] = fn(id)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, item := range result.Items {
+		if item.Err != nil {
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,365 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type JobBulkPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+}
+
+func (suite *JobBulkPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+// bulkJobID returns a deterministic job UUID for n.
+func bulkJobID(
+	n int,
+) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", n)
+}
+
+// bulkJobServer fakes the job list, retry, delete, and queue stats
+// endpoints. IDs in failing return 500 for retry and delete; the
+// "list" key makes listing return 401.
+type bulkJobServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	retried map[string]string
+	deleted []string
+}
+
+func newBulkJobServer(
+	jobs []map[string]any,
+	dlq int,
+	failing map[string]bool,
+) *bulkJobServer {
+	s := &bulkJobServer{retried: make(map[string]string)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/job" && r.Method == http.MethodGet:
+			if failing["list"] {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+
+				return
+			}
+
+			status := r.URL.Query().Get("status")
+
+			var items []map[string]any
+			for _, j := range jobs {
+				if status == "" || j["status"] == status {
+					items = append(items, j)
+				}
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"items":       items,
+				"total_items": len(items),
+			})
+		case r.URL.Path == "/job/status":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"total_jobs": len(jobs),
+				"dlq_count":  dlq,
+			})
+		case strings.HasSuffix(r.URL.Path, "/retry"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/job/"), "/retry")
+			if failing[id] {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"boom"}`))
+
+				return
+			}
+
+			var body struct {
+				Target string `json:"target_hostname"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			s.mu.Lock()
+			s.retried[id] = body.Target
+			s.mu.Unlock()
+
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"job_id": bulkJobID(900),
+				"status": "pending",
+			})
+		case r.Method == http.MethodDelete:
+			id := strings.TrimPrefix(r.URL.Path, "/job/")
+			if failing[id] {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"boom"}`))
+
+				return
+			}
+
+			s.mu.Lock()
+			s.deleted = append(s.deleted, id)
+			s.mu.Unlock()
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return s
+}
+
+func (suite *JobBulkPublicTestSuite) TestRetryFailed() {
+	jobs := []map[string]any{
+		{"id": bulkJobID(1), "status": "failed"},
+		{"id": bulkJobID(2), "status": "completed"},
+		{"id": bulkJobID(3), "status": "failed"},
+		{"id": bulkJobID(4), "status": "failed"},
+	}
+
+	tests := []struct {
+		name         string
+		failing      map[string]bool
+		opts         []osapi.BulkOption
+		validateFunc func(*osapi.BulkResult, error, *bulkJobServer)
+	}{
+		{
+			name: "when all retries succeed retries only failed jobs",
+			opts: []osapi.BulkOption{osapi.WithConcurrency(2)},
+			validateFunc: func(r *osapi.BulkResult, err error, s *bulkJobServer) {
+				suite.NoError(err)
+				suite.Equal(3, r.Matched)
+				suite.Equal(3, r.Succeeded)
+				suite.Zero(r.Failed)
+				suite.NoError(r.Err())
+				suite.Equal(bulkJobID(1), r.Items[0].JobID)
+				suite.Equal(bulkJobID(900), r.Items[0].NewJobID)
+				suite.Len(s.retried, 3)
+				suite.NotContains(s.retried, bulkJobID(2))
+			},
+		},
+		{
+			name: "when retargeted sends the new target",
+			opts: []osapi.BulkOption{osapi.WithRetryTarget("web-02")},
+			validateFunc: func(_ *osapi.BulkResult, err error, s *bulkJobServer) {
+				suite.NoError(err)
+				for _, target := range s.retried {
+					suite.Equal("web-02", target)
+				}
+			},
+		},
+		{
+			name:    "when a retry fails records the error",
+			failing: map[string]bool{bulkJobID(3): true},
+			validateFunc: func(r *osapi.BulkResult, err error, _ *bulkJobServer) {
+				suite.NoError(err)
+				suite.Equal(2, r.Succeeded)
+				suite.Equal(1, r.Failed)
+
+				var serverErr *osapi.ServerError
+				suite.True(errors.As(r.Items[1].Err, &serverErr))
+				suite.ErrorContains(r.Err(), "job "+bulkJobID(3))
+			},
+		},
+		{
+			name: "when dry run makes no changes",
+			opts: []osapi.BulkOption{osapi.WithDryRun()},
+			validateFunc: func(r *osapi.BulkResult, err error, s *bulkJobServer) {
+				suite.NoError(err)
+				suite.True(r.DryRun)
+				suite.Equal(3, r.Matched)
+				suite.Len(r.Items, 3)
+				suite.Empty(s.retried)
+			},
+		},
+		{
+			name:    "when listing fails returns error",
+			failing: map[string]bool{"list": true},
+			validateFunc: func(r *osapi.BulkResult, err error, s *bulkJobServer) {
+				suite.Nil(r)
+				suite.ErrorContains(err, "retry failed jobs")
+
+				var authErr *osapi.AuthError
+				suite.True(errors.As(err, &authErr))
+				suite.Empty(s.retried)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := newBulkJobServer(jobs, 0, tc.failing)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			result, err := sut.Job.RetryFailed(suite.ctx, osapi.ListParams{}, tc.opts...)
+			tc.validateFunc(result, err, server)
+		})
+	}
+}
+
+func (suite *JobBulkPublicTestSuite) TestPurgeCompleted() {
+	old := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	jobs := []map[string]any{
+		{"id": bulkJobID(1), "status": "completed", "created": old},
+		{"id": bulkJobID(2), "status": "completed", "created": recent},
+		{"id": bulkJobID(3), "status": "failed", "created": old},
+		{"id": bulkJobID(4), "status": "completed", "created": "yesterday"},
+		{"id": bulkJobID(5), "status": "completed", "created": old},
+	}
+
+	server := newBulkJobServer(jobs, 0, map[string]bool{bulkJobID(5): true})
+	defer server.Close()
+
+	sut := osapi.New(server.URL, "test-token")
+
+	result, err := sut.Job.PurgeCompleted(suite.ctx, 24*time.Hour)
+	suite.NoError(err)
+	suite.Equal(2, result.Matched)
+	suite.Equal(1, result.Succeeded)
+	suite.Equal(1, result.Failed)
+	suite.Equal([]string{bulkJobID(1)}, server.deleted)
+}
+
+func (suite *JobBulkPublicTestSuite) TestDeleteBatch() {
+	tests := []struct {
+		name         string
+		ids          []string
+		canceled     bool
+		validateFunc func(*osapi.BulkResult, *bulkJobServer)
+	}{
+		{
+			name: "when an ID is invalid records the error",
+			ids:  []string{bulkJobID(1), bulkJobID(2), "not-a-uuid"},
+			validateFunc: func(r *osapi.BulkResult, s *bulkJobServer) {
+				suite.Equal(3, r.Matched)
+				suite.Equal(2, r.Succeeded)
+				suite.ErrorContains(r.Items[2].Err, "invalid job ID")
+
+				slices.Sort(s.deleted)
+				suite.Equal([]string{bulkJobID(1), bulkJobID(2)}, s.deleted)
+			},
+		},
+		{
+			name:     "when context is canceled fails every item",
+			ids:      []string{bulkJobID(1), bulkJobID(2)},
+			canceled: true,
+			validateFunc: func(r *osapi.BulkResult, s *bulkJobServer) {
+				suite.Equal(2, r.Failed)
+				suite.ErrorIs(r.Err(), context.Canceled)
+				suite.Empty(s.deleted)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := newBulkJobServer(nil, 0, nil)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			ctx, cancel := context.WithCancel(suite.ctx)
+			defer cancel()
+			if tc.canceled {
+				cancel()
+			}
+
+			result := sut.Job.DeleteBatch(ctx, tc.ids, osapi.WithConcurrency(1))
+			tc.validateFunc(result, server)
+		})
+	}
+}
+
+func (suite *JobBulkPublicTestSuite) TestDeadLetters() {
+	jobs := []map[string]any{
+		{"id": bulkJobID(1), "status": "failed"},
+		{"id": bulkJobID(2), "status": "completed"},
+	}
+
+	tests := []struct {
+		name         string
+		dlq          int
+		validateFunc func(*osapi.Response[osapi.DeadLetterReport], error)
+	}{
+		{
+			name: "when dlq has entries reports count and failed jobs",
+			dlq:  3,
+			validateFunc: func(resp *osapi.Response[osapi.DeadLetterReport], err error) {
+				suite.NoError(err)
+				suite.Equal(3, resp.Data.Count)
+				suite.Len(resp.Data.FailedJobs, 1)
+				suite.Equal(bulkJobID(1), resp.Data.FailedJobs[0].ID)
+			},
+		},
+		{
+			name: "when dlq is empty still lists failed jobs",
+			validateFunc: func(resp *osapi.Response[osapi.DeadLetterReport], err error) {
+				suite.NoError(err)
+				suite.Zero(resp.Data.Count)
+				suite.Len(resp.Data.FailedJobs, 1)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := newBulkJobServer(jobs, tc.dlq, nil)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			resp, err := sut.Job.DeadLetters(suite.ctx)
+			tc.validateFunc(resp, err)
+		})
+	}
+}
+
+func TestJobBulkPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(JobBulkPublicTestSuite))
+}
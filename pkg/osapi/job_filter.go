@@ -0,0 +1,177 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortOrder orders jobs by creation time.
+type SortOrder string
+
+// Sort orders accepted by ListParams.
+const (
+	SortNewestFirst SortOrder = "newest"
+	SortOldestFirst SortOrder = "oldest"
+)
+
+// clientSide reports whether any filter must be applied client-side.
+func (p ListParams) clientSide() bool {
+	return p.Hostname != "" ||
+		(p.Target != "" && p.Target != "_all" && p.Target != "_any") ||
+		p.OperationType != "" ||
+		!p.CreatedAfter.IsZero() ||
+		!p.CreatedBefore.IsZero() ||
+		p.Sort != ""
+}
+
+// matches reports whether j satisfies the client-side filters. hosts
+// are the hostnames p.Target resolved to, or nil when Target does not
+// restrict the jobs.
+func (p ListParams) matches(
+	j JobDetail,
+	hosts []string,
+) bool {
+	if p.Hostname != "" && !jobOnHost(j, p.Hostname) {
+		return false
+	}
+
+	if hosts != nil && !slices.ContainsFunc(hosts, func(h string) bool {
+		return jobOnHost(j, h)
+	}) {
+		return false
+	}
+
+	if p.OperationType != "" && j.OperationType() != p.OperationType {
+		return false
+	}
+
+	if p.CreatedAfter.IsZero() && p.CreatedBefore.IsZero() {
+		return true
+	}
+
+	created, ok := j.CreatedAt()
+	if !ok {
+		return false
+	}
+
+	if !p.CreatedAfter.IsZero() && created.Before(p.CreatedAfter) {
+		return false
+	}
+
+	if !p.CreatedBefore.IsZero() && !created.Before(p.CreatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// resolveTarget returns the hostnames target resolves to, or nil when
+// it does not restrict jobs. Label selectors are resolved against the
+// current agent list; a selector matching no agent matches no job.
+func (s *JobService) resolveTarget(
+	ctx context.Context,
+	target string,
+) ([]string, error) {
+	switch {
+	case target == "" || target == "_all" || target == "_any":
+		return nil, nil
+	case !strings.Contains(target, ":"):
+		return []string{target}, nil
+	}
+
+	resp, err := s.agents.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("resolve target %s: %w", target, err)
+	}
+
+	hosts := []string{}
+	for _, a := range MatchAgents(resp.Data.Agents, target) {
+		hosts = append(hosts, a.Hostname)
+	}
+
+	return hosts, nil
+}
+
+// jobOnHost reports whether hostname processed j or reported state
+// for it.
+func jobOnHost(
+	j JobDetail,
+	hostname string,
+) bool {
+	if j.Hostname == hostname {
+		return true
+	}
+
+	if _, ok := j.AgentStates[hostname]; ok {
+		return true
+	}
+
+	_, ok := j.Responses[hostname]
+
+	return ok
+}
+
+// sortJobs orders jobs by creation time. Jobs without a parseable
+// timestamp sort as oldest. An empty order leaves jobs unchanged.
+func sortJobs(
+	jobs []JobDetail,
+	order SortOrder,
+) {
+	if order == "" {
+		return
+	}
+
+	slices.SortStableFunc(jobs, func(a, b JobDetail) int {
+		ta, _ := a.CreatedAt()
+		tb, _ := b.CreatedAt()
+
+		c := ta.Compare(tb)
+		if order == SortNewestFirst {
+			c = -c
+		}
+
+		return cmp.Or(c, cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// windowJobs applies offset and a non-zero limit to jobs.
+func windowJobs(
+	jobs []JobDetail,
+	offset int,
+	limit int,
+) []JobDetail {
+	if offset >= len(jobs) {
+		return []JobDetail{}
+	}
+
+	jobs = jobs[offset:]
+
+	if limit > 0 && limit < len(jobs) {
+		jobs = jobs[:limit]
+	}
+
+	return jobs
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JobFilterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *JobFilterTestSuite) TestMatches() {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	job := JobDetail{
+		ID:        "a",
+		Hostname:  "web-01",
+		Created:   base.Format(time.RFC3339),
+		Operation: map[string]any{"type": "node.disk.get"},
+		Responses: map[string]AgentJobResponse{"web-02": {}},
+	}
+
+	tests := []struct {
+		name   string
+		params ListParams
+		hosts  []string
+		job    JobDetail
+		want   bool
+	}{
+		{
+			name: "when no client filters are set",
+			job:  job,
+			want: true,
+		},
+		{
+			name:   "when hostname matches a broadcast response",
+			params: ListParams{Hostname: "web-02"},
+			job:    job,
+			want:   true,
+		},
+		{
+			name:   "when hostname does not match",
+			params: ListParams{Hostname: "db-01"},
+			job:    job,
+		},
+		{
+			name:  "when a resolved target host matches",
+			hosts: []string{"db-01", "web-02"},
+			job:   job,
+			want:  true,
+		},
+		{
+			name:  "when no resolved target host matches",
+			hosts: []string{"db-01"},
+			job:   job,
+		},
+		{
+			name:  "when target resolved to no hosts",
+			hosts: []string{},
+			job:   job,
+		},
+		{
+			name:   "when operation type does not match",
+			params: ListParams{OperationType: "node.load.get"},
+			job:    job,
+		},
+		{
+			name:   "when created equals the after bound",
+			params: ListParams{CreatedAfter: base},
+			job:    job,
+			want:   true,
+		},
+		{
+			name:   "when created equals the before bound",
+			params: ListParams{CreatedBefore: base},
+			job:    job,
+		},
+		{
+			name:   "when created is unparseable and a window is set",
+			params: ListParams{CreatedAfter: base.Add(-time.Hour)},
+			job:    JobDetail{Created: "yesterday"},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.Equal(tc.want, tc.params.matches(tc.job, tc.hosts))
+		})
+	}
+}
+
+func (suite *JobFilterTestSuite) TestSortJobs() {
+	jobs := func() []JobDetail {
+		return []JobDetail{
+			{ID: "b", Created: "2026-01-01T02:00:00Z"},
+			{ID: "x", Created: "unknown"},
+			{ID: "a", Created: "2026-01-01T02:00:00Z"},
+			{ID: "c", Created: "2026-01-01T01:00:00Z"},
+		}
+	}
+
+	order := func(jobs []JobDetail) []string {
+		ids := make([]string, 0, len(jobs))
+		for _, j := range jobs {
+			ids = append(ids, j.ID)
+		}
+
+		return ids
+	}
+
+	tests := []struct {
+		name  string
+		order SortOrder
+		want  []string
+	}{
+		{
+			name: "when order is empty keeps input order",
+			want: []string{"b", "x", "a", "c"},
+		},
+		{
+			name:  "when oldest first sorts unparseable first",
+			order: SortOldestFirst,
+			want:  []string{"x", "c", "a", "b"},
+		},
+		{
+			name:  "when newest first breaks ties by ID",
+			order: SortNewestFirst,
+			want:  []string{"a", "b", "c", "x"},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			j := jobs()
+			sortJobs(j, tc.order)
+			suite.Equal(tc.want, order(j))
+		})
+	}
+}
+
+func TestJobFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(JobFilterTestSuite))
+}
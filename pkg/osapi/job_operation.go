@@ -0,0 +1,482 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Job types accepted by the job queue.
+const (
+	JobTypeCommandExec   = "command.exec.execute"
+	JobTypeCommandShell  = "command.shell.execute"
+	JobTypeFileDeploy    = "file.deploy.execute"
+	JobTypeFileStatus    = "file.status.get"
+	JobTypeDNSGet        = "network.dns.get"
+	JobTypeDNSUpdate     = "network.dns.update"
+	JobTypePing          = "network.ping.do"
+	JobTypeNodeHostname  = "node.hostname.get"
+	JobTypeNodeStatus    = "node.status.get"
+	JobTypeNodeDisk      = "node.disk.get"
+	JobTypeNodeMemory    = "node.memory.get"
+	JobTypeNodeUptime    = "node.uptime.get"
+	JobTypeNodeLoad      = "node.load.get"
+	maxCommandTimeoutSec = 300
+)
+
+// factPrefix marks values resolved agent-side from facts.
+const factPrefix = "@fact."
+
+// JobOperation is a typed job operation accepted by JobService.Submit.
+type JobOperation interface {
+	// Type returns the job type (e.g., "command.exec.execute").
+	Type() string
+
+	// Params returns the operation data sent with the job, or nil when
+	// the operation takes no parameters.
+	Params() map[string]any
+
+	// Validate checks the operation before it is submitted.
+	Validate() error
+}
+
+// CommandExecOp executes a command directly without a shell.
+type CommandExecOp struct {
+	// Command is the binary to execute (required).
+	Command string
+
+	// Args is the argument list passed to the command.
+	Args []string
+
+	// Cwd is the working directory. Empty uses the agent default.
+	Cwd string
+
+	// Timeout in seconds (1-300). Zero uses the server default.
+	Timeout int
+}
+
+// Type returns the job type.
+func (o CommandExecOp) Type() string { return JobTypeCommandExec }
+
+// Params returns the operation data.
+func (o CommandExecOp) Params() map[string]any {
+	p := map[string]any{"command": o.Command}
+
+	if len(o.Args) > 0 {
+		p["args"] = o.Args
+	}
+
+	if o.Cwd != "" {
+		p["cwd"] = o.Cwd
+	}
+
+	if o.Timeout > 0 {
+		p["timeout"] = o.Timeout
+	}
+
+	return p
+}
+
+// Validate checks the command and timeout.
+func (o CommandExecOp) Validate() error {
+	if o.Command == "" {
+		return errors.New("command is required")
+	}
+
+	return validateTimeout(o.Timeout)
+}
+
+// CommandShellOp executes a command string through /bin/sh -c.
+type CommandShellOp struct {
+	// Command is the shell command string (required).
+	Command string
+
+	// Cwd is the working directory. Empty uses the agent default.
+	Cwd string
+
+	// Timeout in seconds (1-300). Zero uses the server default.
+	Timeout int
+}
+
+// Type returns the job type.
+func (o CommandShellOp) Type() string { return JobTypeCommandShell }
+
+// Params returns the operation data.
+func (o CommandShellOp) Params() map[string]any {
+	p := map[string]any{"command": o.Command}
+
+	if o.Cwd != "" {
+		p["cwd"] = o.Cwd
+	}
+
+	if o.Timeout > 0 {
+		p["timeout"] = o.Timeout
+	}
+
+	return p
+}
+
+// Validate checks the command and timeout.
+func (o CommandShellOp) Validate() error {
+	if o.Command == "" {
+		return errors.New("command is required")
+	}
+
+	return validateTimeout(o.Timeout)
+}
+
+// FileDeployOp deploys a file from the Object Store to the target.
+type FileDeployOp struct {
+	// ObjectName is the name of the file in the Object Store (required).
+	ObjectName string
+
+	// Path is the destination path on the target filesystem (required).
+	Path string
+
+	// ContentType is "raw" or "template" (required).
+	ContentType string
+
+	// Mode is the file permission mode (e.g., "0644"). Optional.
+	Mode string
+
+	// Owner is the file owner user. Optional.
+	Owner string
+
+	// Group is the file owner group. Optional.
+	Group string
+
+	// Vars are template variables when ContentType is "template". Optional.
+	Vars map[string]any
+}
+
+// Type returns the job type.
+func (o FileDeployOp) Type() string { return JobTypeFileDeploy }
+
+// Params returns the operation data.
+func (o FileDeployOp) Params() map[string]any {
+	p := map[string]any{
+		"object_name":  o.ObjectName,
+		"path":         o.Path,
+		"content_type": o.ContentType,
+	}
+
+	if o.Mode != "" {
+		p["mode"] = o.Mode
+	}
+
+	if o.Owner != "" {
+		p["owner"] = o.Owner
+	}
+
+	if o.Group != "" {
+		p["group"] = o.Group
+	}
+
+	if len(o.Vars) > 0 {
+		p["vars"] = o.Vars
+	}
+
+	return p
+}
+
+// Validate checks the object name, path, content type, and mode.
+func (o FileDeployOp) Validate() error {
+	if o.ObjectName == "" {
+		return errors.New("object name is required")
+	}
+
+	if len(o.ObjectName) > 255 {
+		return errors.New("object name must be at most 255 characters")
+	}
+
+	if o.Path == "" {
+		return errors.New("path is required")
+	}
+
+	switch o.ContentType {
+	case "raw":
+		if len(o.Vars) > 0 {
+			return errors.New("vars require content type \"template\"")
+		}
+	case "template":
+	default:
+		return fmt.Errorf("content type must be \"raw\" or \"template\", got %q", o.ContentType)
+	}
+
+	if o.Mode != "" {
+		mode, err := strconv.ParseUint(o.Mode, 8, 32)
+		if err != nil || mode > 0o7777 {
+			return fmt.Errorf("invalid file mode %q", o.Mode)
+		}
+	}
+
+	return nil
+}
+
+// FileStatusOp checks the deployment status of a file on the target.
+type FileStatusOp struct {
+	// Path is the file path to check (required).
+	Path string
+}
+
+// Type returns the job type.
+func (o FileStatusOp) Type() string { return JobTypeFileStatus }
+
+// Params returns the operation data.
+func (o FileStatusOp) Params() map[string]any {
+	return map[string]any{"path": o.Path}
+}
+
+// Validate checks the path.
+func (o FileStatusOp) Validate() error {
+	if o.Path == "" {
+		return errors.New("path is required")
+	}
+
+	return nil
+}
+
+// DNSGetOp retrieves DNS configuration for a network interface.
+type DNSGetOp struct {
+	// Interface is the network interface name (required).
+	Interface string
+}
+
+// Type returns the job type.
+func (o DNSGetOp) Type() string { return JobTypeDNSGet }
+
+// Params returns the operation data.
+func (o DNSGetOp) Params() map[string]any {
+	return map[string]any{"interface": o.Interface}
+}
+
+// Validate checks the interface name.
+func (o DNSGetOp) Validate() error {
+	return validateInterface(o.Interface)
+}
+
+// DNSUpdateOp updates DNS configuration for a network interface.
+type DNSUpdateOp struct {
+	// Interface is the network interface name (required).
+	Interface string
+
+	// Servers are the DNS server IP addresses.
+	Servers []string
+
+	// SearchDomains are the DNS search domains.
+	SearchDomains []string
+}
+
+// Type returns the job type.
+func (o DNSUpdateOp) Type() string { return JobTypeDNSUpdate }
+
+// Params returns the operation data.
+func (o DNSUpdateOp) Params() map[string]any {
+	p := map[string]any{"interface": o.Interface}
+
+	if len(o.Servers) > 0 {
+		p["servers"] = o.Servers
+	}
+
+	if len(o.SearchDomains) > 0 {
+		p["search_domains"] = o.SearchDomains
+	}
+
+	return p
+}
+
+// Validate checks the interface name, servers, and search domains.
+func (o DNSUpdateOp) Validate() error {
+	if err := validateInterface(o.Interface); err != nil {
+		return err
+	}
+
+	if len(o.Servers) == 0 && len(o.SearchDomains) == 0 {
+		return errors.New("servers or search domains are required")
+	}
+
+	for _, s := range o.Servers {
+		if _, err := netip.ParseAddr(s); err != nil {
+			return fmt.Errorf("invalid DNS server %q", s)
+		}
+	}
+
+	for _, d := range o.SearchDomains {
+		if d == "" || strings.ContainsAny(d, " /") {
+			return fmt.Errorf("invalid search domain %q", d)
+		}
+	}
+
+	return nil
+}
+
+// PingOp pings an address from the target.
+type PingOp struct {
+	// Address is the IP address or @fact. reference to ping (required).
+	Address string
+}
+
+// Type returns the job type.
+func (o PingOp) Type() string { return JobTypePing }
+
+// Params returns the operation data.
+func (o PingOp) Params() map[string]any {
+	return map[string]any{"address": o.Address}
+}
+
+// Validate checks the address is an IP or fact reference.
+func (o PingOp) Validate() error {
+	if o.Address == "" {
+		return errors.New("address is required")
+	}
+
+	if strings.HasPrefix(o.Address, factPrefix) {
+		return nil
+	}
+
+	if _, err := netip.ParseAddr(o.Address); err != nil {
+		return fmt.Errorf("invalid address %q", o.Address)
+	}
+
+	return nil
+}
+
+// NodeHostnameOp retrieves the target hostname.
+type NodeHostnameOp struct{}
+
+// NodeStatusOp retrieves the full node status.
+type NodeStatusOp struct{}
+
+// NodeDiskOp retrieves disk usage.
+type NodeDiskOp struct{}
+
+// NodeMemoryOp retrieves memory statistics.
+type NodeMemoryOp struct{}
+
+// NodeUptimeOp retrieves system uptime.
+type NodeUptimeOp struct{}
+
+// NodeLoadOp retrieves load averages.
+type NodeLoadOp struct{}
+
+// Type returns the job type.
+func (NodeHostnameOp) Type() string { return JobTypeNodeHostname }
+
+// Type returns the job type.
+func (NodeStatusOp) Type() string { return JobTypeNodeStatus }
+
+// Type returns the job type.
+func (NodeDiskOp) Type() string { return JobTypeNodeDisk }
+
+// Type returns the job type.
+func (NodeMemoryOp) Type() string { return JobTypeNodeMemory }
+
+// Type returns the job type.
+func (NodeUptimeOp) Type() string { return JobTypeNodeUptime }
+
+// Type returns the job type.
+func (NodeLoadOp) Type() string { return JobTypeNodeLoad }
+
+// Params returns nil; node queries take no parameters.
+func (NodeHostnameOp) Params() map[string]any { return nil }
+
+// Params returns nil; node queries take no parameters.
+func (NodeStatusOp) Params() map[string]any { return nil }
+
+// Params returns nil; node queries take no parameters.
+func (NodeDiskOp) Params() map[string]any { return nil }
+
+// Params returns nil; node queries take no parameters.
+func (NodeMemoryOp) Params() map[string]any { return nil }
+
+// Params returns nil; node queries take no parameters.
+func (NodeUptimeOp) Params() map[string]any { return nil }
+
+// Params returns nil; node queries take no parameters.
+func (NodeLoadOp) Params() map[string]any { return nil }
+
+// Validate always succeeds; node queries take no parameters.
+func (NodeHostnameOp) Validate() error { return nil }
+
+// Validate always succeeds; node queries take no parameters.
+func (NodeStatusOp) Validate() error { return nil }
+
+// Validate always succeeds; node queries take no parameters.
+func (NodeDiskOp) Validate() error { return nil }
+
+// Validate always succeeds; node queries take no parameters.
+func (NodeMemoryOp) Validate() error { return nil }
+
+// Validate always succeeds; node queries take no parameters.
+func (NodeUptimeOp) Validate() error { return nil }
+
+// Validate always succeeds; node queries take no parameters.
+func (NodeLoadOp) Validate() error { return nil }
+
+// operationBody builds the job operation payload for op.
+func operationBody(
+	op JobOperation,
+) map[string]any {
+	body := map[string]any{"type": op.Type()}
+
+	if params := op.Params(); len(params) > 0 {
+		body["data"] = params
+	}
+
+	return body
+}
+
+// validateTimeout checks an optional command timeout.
+func validateTimeout(
+	timeout int,
+) error {
+	if timeout < 0 || timeout > maxCommandTimeoutSec {
+		return fmt.Errorf("timeout must be between 1 and %d seconds", maxCommandTimeoutSec)
+	}
+
+	return nil
+}
+
+// validateInterface checks an interface name is alphanumeric or a
+// fact reference.
+func validateInterface(
+	name string,
+) error {
+	if name == "" {
+		return errors.New("interface is required")
+	}
+
+	if strings.HasPrefix(name, factPrefix) {
+		return nil
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return fmt.Errorf("invalid interface name %q", name)
+		}
+	}
+
+	return nil
+}
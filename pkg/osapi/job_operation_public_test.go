@@ -0,0 +1,314 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type JobOperationPublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *JobOperationPublicTestSuite) TestValidate() {
+	tests := []struct {
+		name    string
+		op      osapi.JobOperation
+		wantErr string
+	}{
+		{
+			name: "when exec is valid",
+			op:   osapi.CommandExecOp{Command: "ls", Timeout: 300},
+		},
+		{
+			name:    "when exec command is missing",
+			op:      osapi.CommandExecOp{},
+			wantErr: "command is required",
+		},
+		{
+			name:    "when exec timeout is out of range",
+			op:      osapi.CommandExecOp{Command: "ls", Timeout: 301},
+			wantErr: "timeout must be between 1 and 300 seconds",
+		},
+		{
+			name:    "when shell timeout is negative",
+			op:      osapi.CommandShellOp{Command: "ls", Timeout: -1},
+			wantErr: "timeout must be between 1 and 300 seconds",
+		},
+		{
+			name: "when deploy is valid template",
+			op: osapi.FileDeployOp{
+				ObjectName:  "nginx.conf",
+				Path:        "/etc/nginx/nginx.conf",
+				ContentType: "template",
+				Mode:        "0644",
+				Vars:        map[string]any{"port": 80},
+			},
+		},
+		{
+			name:    "when deploy object name is missing",
+			op:      osapi.FileDeployOp{Path: "/tmp/a", ContentType: "raw"},
+			wantErr: "object name is required",
+		},
+		{
+			name: "when deploy object name is too long",
+			op: osapi.FileDeployOp{
+				ObjectName:  strings.Repeat("a", 256),
+				Path:        "/tmp/a",
+				ContentType: "raw",
+			},
+			wantErr: "object name must be at most 255 characters",
+		},
+		{
+			name:    "when deploy path is missing",
+			op:      osapi.FileDeployOp{ObjectName: "a", ContentType: "raw"},
+			wantErr: "path is required",
+		},
+		{
+			name:    "when deploy content type is unknown",
+			op:      osapi.FileDeployOp{ObjectName: "a", Path: "/tmp/a", ContentType: "binary"},
+			wantErr: `content type must be "raw" or "template", got "binary"`,
+		},
+		{
+			name: "when deploy vars are set on raw content",
+			op: osapi.FileDeployOp{
+				ObjectName:  "a",
+				Path:        "/tmp/a",
+				ContentType: "raw",
+				Vars:        map[string]any{"k": "v"},
+			},
+			wantErr: `vars require content type "template"`,
+		},
+		{
+			name: "when deploy mode is not octal",
+			op: osapi.FileDeployOp{
+				ObjectName:  "a",
+				Path:        "/tmp/a",
+				ContentType: "raw",
+				Mode:        "0999",
+			},
+			wantErr: `invalid file mode "0999"`,
+		},
+		{
+			name:    "when status path is missing",
+			op:      osapi.FileStatusOp{},
+			wantErr: "path is required",
+		},
+		{
+			name: "when dns get uses a fact reference",
+			op:   osapi.DNSGetOp{Interface: "@fact.interface.primary"},
+		},
+		{
+			name:    "when dns get interface is missing",
+			op:      osapi.DNSGetOp{},
+			wantErr: "interface is required",
+		},
+		{
+			name:    "when dns get interface is invalid",
+			op:      osapi.DNSGetOp{Interface: "eth0; rm"},
+			wantErr: `invalid interface name "eth0; rm"`,
+		},
+		{
+			name: "when dns update is valid",
+			op: osapi.DNSUpdateOp{
+				Interface:     "eth0",
+				Servers:       []string{"8.8.8.8", "2001:4860:4860::8888"},
+				SearchDomains: []string{"example.com"},
+			},
+		},
+		{
+			name:    "when dns update has nothing to set",
+			op:      osapi.DNSUpdateOp{Interface: "eth0"},
+			wantErr: "servers or search domains are required",
+		},
+		{
+			name:    "when dns update server is not an IP",
+			op:      osapi.DNSUpdateOp{Interface: "eth0", Servers: []string{"dns.google"}},
+			wantErr: `invalid DNS server "dns.google"`,
+		},
+		{
+			name:    "when dns update search domain is invalid",
+			op:      osapi.DNSUpdateOp{Interface: "eth0", SearchDomains: []string{"a b"}},
+			wantErr: `invalid search domain "a b"`,
+		},
+		{
+			name: "when ping address is a fact reference",
+			op:   osapi.PingOp{Address: "@fact.gateway"},
+		},
+		{
+			name:    "when ping address is missing",
+			op:      osapi.PingOp{},
+			wantErr: "address is required",
+		},
+		{
+			name:    "when ping address is invalid",
+			op:      osapi.PingOp{Address: "not-an-ip"},
+			wantErr: `invalid address "not-an-ip"`,
+		},
+		{
+			name: "when node query",
+			op:   osapi.NodeDiskOp{},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			err := tc.op.Validate()
+
+			if tc.wantErr == "" {
+				suite.NoError(err)
+			} else {
+				suite.EqualError(err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func (suite *JobOperationPublicTestSuite) TestTypeAndParams() {
+	tests := []struct {
+		name       string
+		op         osapi.JobOperation
+		wantType   string
+		wantParams map[string]any
+	}{
+		{
+			name:     "when exec omits optional fields",
+			op:       osapi.CommandExecOp{Command: "uptime"},
+			wantType: "command.exec.execute",
+			wantParams: map[string]any{
+				"command": "uptime",
+			},
+		},
+		{
+			name: "when shell sets all fields",
+			op: osapi.CommandShellOp{
+				Command: "echo hi",
+				Cwd:     "/tmp",
+				Timeout: 5,
+			},
+			wantType: "command.shell.execute",
+			wantParams: map[string]any{
+				"command": "echo hi",
+				"cwd":     "/tmp",
+				"timeout": 5,
+			},
+		},
+		{
+			name: "when deploy sets all fields",
+			op: osapi.FileDeployOp{
+				ObjectName:  "app.conf",
+				Path:        "/etc/app.conf",
+				ContentType: "template",
+				Mode:        "0600",
+				Owner:       "root",
+				Group:       "wheel",
+				Vars:        map[string]any{"a": 1},
+			},
+			wantType: "file.deploy.execute",
+			wantParams: map[string]any{
+				"object_name":  "app.conf",
+				"path":         "/etc/app.conf",
+				"content_type": "template",
+				"mode":         "0600",
+				"owner":        "root",
+				"group":        "wheel",
+				"vars":         map[string]any{"a": 1},
+			},
+		},
+		{
+			name:       "when file status",
+			op:         osapi.FileStatusOp{Path: "/etc/app.conf"},
+			wantType:   "file.status.get",
+			wantParams: map[string]any{"path": "/etc/app.conf"},
+		},
+		{
+			name:       "when dns get",
+			op:         osapi.DNSGetOp{Interface: "eth0"},
+			wantType:   "network.dns.get",
+			wantParams: map[string]any{"interface": "eth0"},
+		},
+		{
+			name: "when dns update",
+			op: osapi.DNSUpdateOp{
+				Interface: "eth0",
+				Servers:   []string{"1.1.1.1"},
+			},
+			wantType: "network.dns.update",
+			wantParams: map[string]any{
+				"interface": "eth0",
+				"servers":   []string{"1.1.1.1"},
+			},
+		},
+		{
+			name:       "when ping",
+			op:         osapi.PingOp{Address: "10.0.0.1"},
+			wantType:   "network.ping.do",
+			wantParams: map[string]any{"address": "10.0.0.1"},
+		},
+		{
+			name:     "when node hostname",
+			op:       osapi.NodeHostnameOp{},
+			wantType: "node.hostname.get",
+		},
+		{
+			name:     "when node status",
+			op:       osapi.NodeStatusOp{},
+			wantType: "node.status.get",
+		},
+		{
+			name:     "when node disk",
+			op:       osapi.NodeDiskOp{},
+			wantType: "node.disk.get",
+		},
+		{
+			name:     "when node memory",
+			op:       osapi.NodeMemoryOp{},
+			wantType: "node.memory.get",
+		},
+		{
+			name:     "when node uptime",
+			op:       osapi.NodeUptimeOp{},
+			wantType: "node.uptime.get",
+		},
+		{
+			name:     "when node load",
+			op:       osapi.NodeLoadOp{},
+			wantType: "node.load.get",
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.Equal(tc.wantType, tc.op.Type())
+			suite.Equal(tc.wantParams, tc.op.Params())
+			suite.NoError(tc.op.Validate())
+		})
+	}
+}
+
+func TestJobOperationPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(JobOperationPublicTestSuite))
+}
@@ -22,11 +22,16 @@ package osapi_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -146,6 +151,117 @@ func (suite *JobPublicTestSuite) TestCreate() {
 	}
 }
 
+func (suite *JobPublicTestSuite) TestSubmit() {
+	tests := []struct {
+		name         string
+		op           osapi.JobOperation
+		target       string
+		validateFunc func(*osapi.Response[osapi.JobCreated], error, map[string]any)
+	}{
+		{
+			name:   "when operation is valid sends typed body",
+			op:     osapi.CommandExecOp{Command: "ls", Args: []string{"-la"}, Timeout: 10},
+			target: "web-01",
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobCreated],
+				err error,
+				body map[string]any,
+			) {
+				suite.NoError(err)
+				suite.Equal("550e8400-e29b-41d4-a716-446655440000", resp.Data.JobID)
+				suite.Equal("web-01", body["target_hostname"])
+
+				op := body["operation"].(map[string]any)
+				suite.Equal("command.exec.execute", op["type"])
+				data := op["data"].(map[string]any)
+				suite.Equal("ls", data["command"])
+				suite.Equal([]any{"-la"}, data["args"])
+				suite.Equal(float64(10), data["timeout"])
+			},
+		},
+		{
+			name:   "when operation has no params omits data",
+			op:     osapi.NodeHostnameOp{},
+			target: "_any",
+			validateFunc: func(
+				_ *osapi.Response[osapi.JobCreated],
+				err error,
+				body map[string]any,
+			) {
+				suite.NoError(err)
+
+				op := body["operation"].(map[string]any)
+				suite.Equal("node.hostname.get", op["type"])
+				suite.NotContains(op, "data")
+			},
+		},
+		{
+			name:   "when operation is invalid returns error without request",
+			op:     osapi.CommandShellOp{},
+			target: "_any",
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobCreated],
+				err error,
+				body map[string]any,
+			) {
+				suite.Nil(resp)
+				suite.EqualError(
+					err,
+					"invalid command.shell.execute operation: command is required",
+				)
+				suite.Nil(body)
+			},
+		},
+		{
+			name:   "when operation is nil returns error",
+			target: "_any",
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobCreated],
+				err error,
+				body map[string]any,
+			) {
+				suite.Nil(resp)
+				suite.EqualError(err, "submit job: operation is required")
+				suite.Nil(body)
+			},
+		},
+		{
+			name: "when target is empty returns error",
+			op:   osapi.NodeLoadOp{},
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobCreated],
+				err error,
+				body map[string]any,
+			) {
+				suite.Nil(resp)
+				suite.EqualError(err, "submit job: target is required")
+				suite.Nil(body)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var body map[string]any
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"job_id":"550e8400-e29b-41d4-a716-446655440000","status":"pending"}`))
+				}),
+			)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			resp, err := sut.Job.Submit(suite.ctx, tc.op, tc.target)
+			tc.validateFunc(resp, err, body)
+		})
+	}
+}
+
 func (suite *JobPublicTestSuite) TestGet() {
 	tests := []struct {
 		name         string
@@ -348,12 +464,24 @@ func (suite *JobPublicTestSuite) TestDelete() {
 }
 
 func (suite *JobPublicTestSuite) TestList() {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := filterJobHandler(filterJobs(base))
+
+	ids := func(resp *osapi.Response[osapi.JobList]) []string {
+		out := make([]string, 0, len(resp.Data.Items))
+		for _, j := range resp.Data.Items {
+			out = append(out, j.ID[len(j.ID)-1:])
+		}
+
+		return out
+	}
+
 	tests := []struct {
 		name         string
 		handler      http.HandlerFunc
 		serverURL    string
 		params       osapi.ListParams
-		validateFunc func(*osapi.Response[osapi.JobList], error)
+		validateFunc func(*osapi.Response[osapi.JobList], error, int32)
 	}{
 		{
 			name: "when no filters returns response",
@@ -363,7 +491,7 @@ func (suite *JobPublicTestSuite) TestList() {
 				_, _ = w.Write([]byte(`{"items":[],"total_items":0}`))
 			},
 			params: osapi.ListParams{},
-			validateFunc: func(resp *osapi.Response[osapi.JobList], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
 				suite.NoError(err)
 				suite.NotNil(resp)
 				suite.Equal(0, resp.Data.TotalItems)
@@ -382,7 +510,7 @@ func (suite *JobPublicTestSuite) TestList() {
 				Limit:  10,
 				Offset: 5,
 			},
-			validateFunc: func(resp *osapi.Response[osapi.JobList], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
 				suite.NoError(err)
 				suite.NotNil(resp)
 			},
@@ -391,7 +519,7 @@ func (suite *JobPublicTestSuite) TestList() {
 			name:      "when HTTP request fails returns error",
 			serverURL: "http://127.0.0.1:0",
 			params:    osapi.ListParams{},
-			validateFunc: func(resp *osapi.Response[osapi.JobList], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
 				suite.Error(err)
 				suite.Nil(resp)
 				suite.Contains(err.Error(), "list jobs")
@@ -405,7 +533,7 @@ func (suite *JobPublicTestSuite) TestList() {
 				_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
 			},
 			params: osapi.ListParams{},
-			validateFunc: func(resp *osapi.Response[osapi.JobList], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
 				suite.Error(err)
 				suite.Nil(resp)
 
@@ -420,7 +548,7 @@ func (suite *JobPublicTestSuite) TestList() {
 				w.WriteHeader(http.StatusOK)
 			},
 			params: osapi.ListParams{},
-			validateFunc: func(resp *osapi.Response[osapi.JobList], err error) {
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
 				suite.Error(err)
 				suite.Nil(resp)
 
@@ -429,6 +557,138 @@ func (suite *JobPublicTestSuite) TestList() {
 				suite.Contains(target.Message, "nil response body")
 			},
 		},
+		{
+			name:    "when only server filters are set sends one request",
+			handler: jobs,
+			params:  osapi.ListParams{Limit: 2},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, requests int32) {
+				suite.NoError(err)
+				suite.Equal([]string{"1", "2"}, ids(resp))
+				suite.Equal(6, resp.Data.TotalItems)
+				suite.NotNil(resp.RawJSON())
+				suite.Equal(int32(1), requests)
+			},
+		},
+		{
+			name:    "when filtering by hostname and operation type",
+			handler: jobs,
+			params: osapi.ListParams{
+				Hostname:      "web-01",
+				OperationType: "file.deploy.execute",
+			},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.NoError(err)
+				suite.Equal([]string{"1", "3", "6"}, ids(resp))
+				suite.Equal(3, resp.Data.TotalItems)
+				suite.Equal(6, resp.Data.StatusCounts["completed"])
+			},
+		},
+		{
+			name:    "when filtering by label target resolves its hosts",
+			handler: jobs,
+			params: osapi.ListParams{
+				Target:        "group:web",
+				OperationType: "command.exec.execute",
+			},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.NoError(err)
+				suite.Equal([]string{"2", "5"}, ids(resp))
+			},
+		},
+		{
+			name:    "when label target matches no agent returns no jobs",
+			handler: jobs,
+			params:  osapi.ListParams{Target: "group:cache"},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.NoError(err)
+				suite.Empty(resp.Data.Items)
+			},
+		},
+		{
+			name:    "when target is a hostname matches like hostname",
+			handler: jobs,
+			params:  osapi.ListParams{Target: "db-01"},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.NoError(err)
+				suite.Equal([]string{"4"}, ids(resp))
+			},
+		},
+		{
+			name:    "when target is _all sends one request",
+			handler: jobs,
+			params:  osapi.ListParams{Target: "_all", Limit: 2},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, requests int32) {
+				suite.NoError(err)
+				suite.Len(resp.Data.Items, 2)
+				suite.Equal(int32(1), requests)
+			},
+		},
+		{
+			name:    "when filtering by created window",
+			handler: jobs,
+			params: osapi.ListParams{
+				CreatedAfter:  base.Add(2 * time.Hour),
+				CreatedBefore: base.Add(5 * time.Hour),
+			},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.NoError(err)
+				suite.Equal([]string{"2", "3", "4"}, ids(resp))
+			},
+		},
+		{
+			name:    "when sorting newest first applies offset and limit after filtering",
+			handler: jobs,
+			params: osapi.ListParams{
+				OperationType: "file.deploy.execute",
+				Sort:          osapi.SortNewestFirst,
+				Offset:        1,
+				Limit:         2,
+			},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.NoError(err)
+				suite.Equal([]string{"4", "3"}, ids(resp))
+				suite.Equal(4, resp.Data.TotalItems)
+				suite.Nil(resp.RawJSON())
+			},
+		},
+		{
+			name:    "when offset is past the filtered jobs returns empty list",
+			handler: jobs,
+			params:  osapi.ListParams{Hostname: "db-01", Offset: 5},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.NoError(err)
+				suite.Empty(resp.Data.Items)
+				suite.Equal(1, resp.Data.TotalItems)
+			},
+		},
+		{
+			name: "when client-side filtering and server returns 403 returns AuthError",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":"forbidden"}`))
+			},
+			params: osapi.ListParams{Hostname: "web-01"},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.Nil(resp)
+
+				var target *osapi.AuthError
+				suite.True(errors.As(err, &target))
+			},
+		},
+		{
+			name: "when target cannot be resolved returns error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"boom"}`))
+			},
+			params: osapi.ListParams{Target: "group:web"},
+			validateFunc: func(resp *osapi.Response[osapi.JobList], err error, _ int32) {
+				suite.Nil(resp)
+				suite.ErrorContains(err, "resolve target group:web")
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -436,12 +696,19 @@ func (suite *JobPublicTestSuite) TestList() {
 			var (
 				serverURL string
 				server    *httptest.Server
+				requests  atomic.Int32
 			)
 
 			if tc.serverURL != "" {
 				serverURL = tc.serverURL
 			} else {
-				server = httptest.NewServer(tc.handler)
+				server = httptest.NewServer(http.HandlerFunc(func(
+					w http.ResponseWriter,
+					r *http.Request,
+				) {
+					requests.Add(1)
+					tc.handler(w, r)
+				}))
 				defer server.Close()
 				serverURL = server.URL
 			}
@@ -453,7 +720,7 @@ func (suite *JobPublicTestSuite) TestList() {
 			)
 
 			resp, err := sut.Job.List(suite.ctx, tc.params)
-			tc.validateFunc(resp, err)
+			tc.validateFunc(resp, err, requests.Load())
 		})
 	}
 }
@@ -686,6 +953,361 @@ func (suite *JobPublicTestSuite) TestRetry() {
 	}
 }
 
+func (suite *JobPublicTestSuite) TestAll() {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := filterJobHandler(filterJobs(base))
+
+	// pagedJobs serves total jobs honoring limit/offset and counts
+	// requests made.
+	pagedJobs := func(
+		total int,
+		reportTotal bool,
+		requests *atomic.Int32,
+	) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+			items := []map[string]any{}
+			for i := offset; i < total && i < offset+limit; i++ {
+				items = append(items, map[string]any{
+					"id":     fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+					"status": r.URL.Query().Get("status"),
+				})
+			}
+
+			body := map[string]any{"items": items}
+			if reportTotal {
+				body["total_items"] = total
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(body)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		total        int
+		reportTotal  bool
+		filter       osapi.ListParams
+		opts         []osapi.PageOption
+		stopAfter    int
+		canceled     bool
+		handler      http.HandlerFunc
+		validateFunc func(ids []string, statuses []string, requests int32, err error)
+	}{
+		{
+			name:        "when jobs span multiple pages yields all of them",
+			total:       5,
+			reportTotal: true,
+			filter:      osapi.ListParams{Status: "completed"},
+			opts:        []osapi.PageOption{osapi.WithPageSize(2)},
+			validateFunc: func(ids []string, statuses []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Len(ids, 5)
+				suite.Equal("00000000-0000-0000-0000-000000000004", ids[4])
+				suite.Equal("completed", statuses[0])
+				suite.Equal(int32(3), requests)
+			},
+		},
+		{
+			name:        "when prefetching yields all jobs",
+			total:       5,
+			reportTotal: true,
+			opts: []osapi.PageOption{
+				osapi.WithPageSize(2),
+				osapi.WithPrefetch(),
+			},
+			validateFunc: func(ids []string, _ []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Len(ids, 5)
+				suite.Equal(int32(3), requests)
+			},
+		},
+		{
+			name:  "when total is unknown stops on short page",
+			total: 4,
+			opts:  []osapi.PageOption{osapi.WithPageSize(2)},
+			validateFunc: func(ids []string, _ []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Len(ids, 4)
+				suite.Equal(int32(3), requests)
+			},
+		},
+		{
+			name:        "when filter has offset and limit honors both",
+			total:       10,
+			reportTotal: true,
+			filter:      osapi.ListParams{Offset: 3, Limit: 4},
+			opts:        []osapi.PageOption{osapi.WithPageSize(3)},
+			validateFunc: func(ids []string, _ []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{
+					"00000000-0000-0000-0000-000000000003",
+					"00000000-0000-0000-0000-000000000004",
+					"00000000-0000-0000-0000-000000000005",
+					"00000000-0000-0000-0000-000000000006",
+				}, ids)
+				suite.Equal(int32(2), requests)
+			},
+		},
+		{
+			name:        "when consumer stops early does not fetch further pages",
+			total:       10,
+			reportTotal: true,
+			opts:        []osapi.PageOption{osapi.WithPageSize(2)},
+			stopAfter:   3,
+			validateFunc: func(ids []string, _ []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Len(ids, 3)
+				suite.Equal(int32(2), requests)
+			},
+		},
+		{
+			name:        "when page size is invalid uses default",
+			total:       3,
+			reportTotal: true,
+			opts:        []osapi.PageOption{osapi.WithPageSize(0)},
+			validateFunc: func(ids []string, _ []string, requests int32, err error) {
+				suite.NoError(err)
+				suite.Len(ids, 3)
+				suite.Equal(int32(1), requests)
+			},
+		},
+		{
+			name: "when server returns error yields it",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+			},
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.Empty(ids)
+
+				var target *osapi.AuthError
+				suite.True(errors.As(err, &target))
+			},
+		},
+		{
+			name:    "when filtering streams matches with offset and limit",
+			handler: jobs,
+			filter:  osapi.ListParams{Hostname: "web-01", Offset: 1, Limit: 2},
+			opts:    []osapi.PageOption{osapi.WithPageSize(2)},
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{jobID(3), jobID(5)}, ids)
+			},
+		},
+		{
+			name:    "when filtering by label target",
+			handler: jobs,
+			filter:  osapi.ListParams{Target: "group:db"},
+			opts:    []osapi.PageOption{osapi.WithPageSize(2)},
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{jobID(4)}, ids)
+			},
+		},
+		{
+			name:    "when sorting oldest first",
+			handler: jobs,
+			filter: osapi.ListParams{
+				OperationType: "command.exec.execute",
+				Sort:          osapi.SortOldestFirst,
+			},
+			opts: []osapi.PageOption{osapi.WithPageSize(2)},
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{jobID(2), jobID(5)}, ids)
+			},
+		},
+		{
+			name:    "when sorting newest first with limit",
+			handler: jobs,
+			filter: osapi.ListParams{
+				Sort:  osapi.SortNewestFirst,
+				Limit: 2,
+			},
+			opts: []osapi.PageOption{osapi.WithPageSize(2)},
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.NoError(err)
+				suite.Equal([]string{jobID(6), jobID(5)}, ids)
+			},
+		},
+		{
+			name: "when client-side filtering and server fails yields error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			filter: osapi.ListParams{Hostname: "web-01"},
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.Empty(ids)
+				suite.Error(err)
+			},
+		},
+		{
+			name: "when target cannot be resolved yields error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"boom"}`))
+			},
+			filter: osapi.ListParams{Target: "group:web"},
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.Empty(ids)
+				suite.ErrorContains(err, "resolve target group:web")
+			},
+		},
+		{
+			name:     "when context is canceled yields its error",
+			total:    3,
+			canceled: true,
+			validateFunc: func(ids []string, _ []string, _ int32, err error) {
+				suite.Empty(ids)
+				suite.ErrorIs(err, context.Canceled)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var requests atomic.Int32
+
+			handler := tc.handler
+			if handler == nil {
+				handler = pagedJobs(tc.total, tc.reportTotal, &requests)
+			}
+
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			var (
+				ids      []string
+				statuses []string
+				iterErr  error
+			)
+
+			ctx, cancel := context.WithCancel(suite.ctx)
+			defer cancel()
+
+			if tc.canceled {
+				cancel()
+			}
+
+			for job, err := range sut.Job.All(ctx, tc.filter, tc.opts...) {
+				if err != nil {
+					iterErr = err
+
+					break
+				}
+
+				ids = append(ids, job.ID)
+				statuses = append(statuses, job.Status)
+
+				if tc.stopAfter > 0 && len(ids) == tc.stopAfter {
+					break
+				}
+			}
+
+			tc.validateFunc(ids, statuses, requests.Load(), iterErr)
+		})
+	}
+}
+
+// filterJobHandler serves jobs from GET /job, honoring the status,
+// limit, and offset query parameters, and three labeled agents from
+// GET /agent.
+func filterJobHandler(
+	jobs []map[string]any,
+) http.HandlerFunc {
+	return func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/agent" {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"agents": []map[string]any{
+					{"hostname": "web-01", "status": "Ready", "labels": map[string]string{"group": "web"}},
+					{"hostname": "web-02", "status": "Ready", "labels": map[string]string{"group": "web"}},
+					{"hostname": "db-01", "status": "Ready", "labels": map[string]string{"group": "db"}},
+				},
+				"total": 3,
+			})
+
+			return
+		}
+
+		q := r.URL.Query()
+
+		var items []map[string]any
+		for _, j := range jobs {
+			if s := q.Get("status"); s == "" || j["status"] == s {
+				items = append(items, j)
+			}
+		}
+
+		total := len(items)
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		items = items[min(offset, total):]
+		if limit > 0 && limit < len(items) {
+			items = items[:limit]
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items":         items,
+			"total_items":   total,
+			"status_counts": map[string]int{"completed": total},
+		})
+	}
+}
+
+// jobID returns the fixture job ID numbered n.
+func jobID(
+	n int,
+) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", n)
+}
+
+// filterJobs returns a fixture of deploy and exec jobs an hour apart.
+func filterJobs(
+	base time.Time,
+) []map[string]any {
+	job := func(n int, host string, opType string) map[string]any {
+		return map[string]any{
+			"id":        jobID(n),
+			"status":    "completed",
+			"hostname":  host,
+			"operation": map[string]any{"type": opType},
+			"created":   base.Add(time.Duration(n) * time.Hour).Format(time.RFC3339),
+		}
+	}
+
+	return []map[string]any{
+		job(1, "web-01", "file.deploy.execute"),
+		job(2, "web-02", "command.exec.execute"),
+		job(3, "web-01", "file.deploy.execute"),
+		job(4, "db-01", "file.deploy.execute"),
+		job(5, "web-01", "command.exec.execute"),
+		{
+			"id":           jobID(6),
+			"status":       "completed",
+			"operation":    map[string]any{"type": "file.deploy.execute"},
+			"created":      base.Add(6 * time.Hour).Format(time.RFC3339),
+			"agent_states": map[string]any{"web-01": map[string]any{"status": "completed"}},
+		},
+	}
+}
+
 func TestJobPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(JobPublicTestSuite))
 }
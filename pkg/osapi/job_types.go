@@ -21,9 +21,33 @@
 package osapi
 
 import (
+	"time"
+
 	"github.com/osapi-io/osapi-sdk/pkg/osapi/gen"
 )
 
+// Job statuses reported by the API.
+const (
+	JobStatusSubmitted      = "submitted"
+	JobStatusProcessing     = "processing"
+	JobStatusCompleted      = "completed"
+	JobStatusFailed         = "failed"
+	JobStatusPartialFailure = "partial_failure"
+)
+
+// IsTerminalJobStatus returns true if the status is one a job never
+// leaves: completed, failed, or partial_failure.
+func IsTerminalJobStatus(
+	status string,
+) bool {
+	switch status {
+	case JobStatusCompleted, JobStatusFailed, JobStatusPartialFailure:
+		return true
+	default:
+		return false
+	}
+}
+
 // JobCreated represents a newly created job response.
 type JobCreated struct {
 	JobID     string
@@ -47,6 +71,25 @@ type JobDetail struct {
 	Timeline    []TimelineEvent
 }
 
+// OperationType returns the job type from the operation data, or an
+// empty string if it is not set.
+func (j JobDetail) OperationType() string {
+	t, _ := j.Operation["type"].(string)
+
+	return t
+}
+
+// CreatedAt parses the creation timestamp. It returns false if the
+// timestamp is missing or not RFC 3339.
+func (j JobDetail) CreatedAt() (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339Nano, j.Created)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
 // AgentState represents an agent's processing state for a broadcast job.
 type AgentState struct {
 	Status   string
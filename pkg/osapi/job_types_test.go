@@ -356,6 +356,26 @@ func (suite *JobTypesTestSuite) TestQueueStatsFromGen() {
 	}
 }
 
+func (suite *JobTypesTestSuite) TestIsTerminalJobStatus() {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{status: JobStatusSubmitted, want: false},
+		{status: JobStatusProcessing, want: false},
+		{status: JobStatusCompleted, want: true},
+		{status: JobStatusFailed, want: true},
+		{status: JobStatusPartialFailure, want: true},
+		{status: "", want: false},
+	}
+
+	for _, tc := range tests {
+		suite.Run("when status is "+tc.status, func() {
+			suite.Equal(tc.want, IsTerminalJobStatus(tc.status))
+		})
+	}
+}
+
 func TestJobTypesTestSuite(t *testing.T) {
 	suite.Run(t, new(JobTypesTestSuite))
 }
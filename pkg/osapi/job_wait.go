@@ -0,0 +1,191 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Default polling intervals used by JobService.Wait.
+const (
+	DefaultWaitInterval    = 500 * time.Millisecond
+	DefaultMaxWaitInterval = 5 * time.Second
+)
+
+// WaitOption configures JobService.Wait and WaitAll.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	onProgress  func(jobID string, hostname string, state AgentState)
+}
+
+// WithPollInterval sets the initial and maximum delay between polls.
+// The delay doubles after every poll until it reaches maxInterval.
+// Pass the same value twice for a fixed interval.
+func WithPollInterval(
+	initial time.Duration,
+	maxInterval time.Duration,
+) WaitOption {
+	return func(o *waitOptions) {
+		o.interval = initial
+		o.maxInterval = maxInterval
+	}
+}
+
+// WithAgentProgress registers a callback invoked whenever an agent's
+// processing state for a job first appears or changes between polls.
+// Callbacks for WaitAll may run concurrently.
+func WithAgentProgress(
+	fn func(jobID string, hostname string, state AgentState),
+) WaitOption {
+	return func(o *waitOptions) { o.onProgress = fn }
+}
+
+// Wait polls a job until it reaches a terminal state. It returns the
+// final job on completion. When the job ends in failed or
+// partial_failure it returns the final job together with a
+// *JobFailedError, so callers can inspect per-agent results.
+func (s *JobService) Wait(
+	ctx context.Context,
+	id string,
+	opts ...WaitOption,
+) (*Response[JobDetail], error) {
+	return s.wait(ctx, id, newWaitOptions(opts))
+}
+
+// WaitAll waits concurrently for every job in ids. The returned slice
+// holds the last polled state of each job in the order of ids. The
+// error joins every per-job error, so errors.As finds each
+// *JobFailedError.
+func (s *JobService) WaitAll(
+	ctx context.Context,
+	ids []string,
+	opts ...WaitOption,
+) ([]JobDetail, error) {
+	options := newWaitOptions(opts)
+	jobs := make([]JobDetail, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			resp, err := s.wait(ctx, id, options)
+			if resp != nil {
+				jobs[i] = resp.Data
+			}
+
+			errs[i] = err
+		}()
+	}
+
+	wg.Wait()
+
+	return jobs, errors.Join(errs...)
+}
+
+// newWaitOptions applies opts over the defaults.
+func newWaitOptions(
+	opts []WaitOption,
+) waitOptions {
+	options := waitOptions{
+		interval:    DefaultWaitInterval,
+		maxInterval: DefaultMaxWaitInterval,
+	}
+
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if options.interval <= 0 {
+		options.interval = DefaultWaitInterval
+	}
+
+	if options.maxInterval < options.interval {
+		options.maxInterval = options.interval
+	}
+
+	return options
+}
+
+// wait polls until the job is terminal. On failure it returns the last
+// polled response alongside the *JobFailedError.
+func (s *JobService) wait(
+	ctx context.Context,
+	id string,
+	options waitOptions,
+) (*Response[JobDetail], error) {
+	seen := make(map[string]AgentState)
+	interval := options.interval
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		resp, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("poll job %s: %w", id, err)
+		}
+
+		job := resp.Data
+
+		if options.onProgress != nil {
+			for host, state := range job.AgentStates {
+				if prev, ok := seen[host]; !ok || prev != state {
+					seen[host] = state
+					options.onProgress(id, host, state)
+				}
+			}
+		}
+
+		if IsTerminalJobStatus(job.Status) {
+			if job.Status == JobStatusCompleted {
+				return resp, nil
+			}
+
+			return resp, &JobFailedError{
+				JobID:       id,
+				Status:      job.Status,
+				Message:     job.Error,
+				AgentStates: job.AgentStates,
+			}
+		}
+
+		timer.Reset(interval)
+		interval = min(interval*2, options.maxInterval)
+	}
+}
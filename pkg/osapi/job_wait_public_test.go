@@ -0,0 +1,335 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type JobWaitPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+}
+
+func (suite *JobWaitPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+// jobPoll is a single GET /job/{id} response.
+type jobPoll struct {
+	status      string
+	err         string
+	agentStates map[string]any
+	code        int
+}
+
+// jobPollServer serves successive polls per job ID from polls,
+// repeating the last response once exhausted.
+func jobPollServer(
+	polls map[string][]jobPoll,
+) *httptest.Server {
+	var mu sync.Mutex
+	idx := make(map[string]int)
+
+	return httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		id := strings.TrimPrefix(r.URL.Path, "/job/")
+
+		mu.Lock()
+		seq := polls[id]
+		i := min(idx[id], len(seq)-1)
+		idx[id]++
+		mu.Unlock()
+
+		p := seq[i]
+
+		code := p.code
+		if code == 0 {
+			code = http.StatusOK
+		}
+
+		body := map[string]any{"id": id, "status": p.status}
+		if p.err != "" {
+			body["error"] = p.err
+		}
+
+		if p.agentStates != nil {
+			body["agent_states"] = p.agentStates
+		}
+
+		if code != http.StatusOK {
+			body = map[string]any{"error": "boom"}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+}
+
+const (
+	waitJobA = "00000000-0000-0000-0000-00000000000a"
+	waitJobB = "00000000-0000-0000-0000-00000000000b"
+)
+
+func (suite *JobWaitPublicTestSuite) TestWait() {
+	tests := []struct {
+		name         string
+		polls        []jobPoll
+		id           string
+		timeout      time.Duration
+		validateFunc func(*osapi.Response[osapi.JobDetail], error, []string)
+	}{
+		{
+			name: "when job completes returns final job",
+			polls: []jobPoll{
+				{status: "submitted"},
+				{status: "processing", agentStates: map[string]any{
+					"web-01": map[string]any{"status": "processing"},
+				}},
+				{status: "completed", agentStates: map[string]any{
+					"web-01": map[string]any{"status": "completed", "duration": "1s"},
+				}},
+			},
+			id: waitJobA,
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobDetail],
+				err error,
+				progress []string,
+			) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.Equal("completed", resp.Data.Status)
+				suite.Equal([]string{
+					"web-01=processing",
+					"web-01=completed",
+				}, progress)
+			},
+		},
+		{
+			name: "when job fails returns JobFailedError",
+			polls: []jobPoll{
+				{status: "failed", err: "disk full", agentStates: map[string]any{
+					"web-01": map[string]any{"status": "failed", "error": "disk full"},
+				}},
+			},
+			id: waitJobA,
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobDetail],
+				err error,
+				_ []string,
+			) {
+				suite.Require().NotNil(resp)
+				suite.Equal("failed", resp.Data.Status)
+
+				var target *osapi.JobFailedError
+				suite.Require().True(errors.As(err, &target))
+				suite.Equal("failed", target.Status)
+				suite.Equal("disk full", target.Message)
+				suite.Equal("disk full", target.AgentStates["web-01"].Error)
+			},
+		},
+		{
+			name: "when job partially fails returns JobFailedError",
+			polls: []jobPoll{
+				{status: "partial_failure", agentStates: map[string]any{
+					"web-01": map[string]any{"status": "completed"},
+					"web-02": map[string]any{"status": "failed"},
+				}},
+			},
+			id: waitJobA,
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobDetail],
+				err error,
+				_ []string,
+			) {
+				suite.Require().NotNil(resp)
+				suite.Equal("partial_failure", resp.Data.Status)
+
+				var target *osapi.JobFailedError
+				suite.Require().True(errors.As(err, &target))
+				suite.Equal("partial_failure", target.Status)
+				suite.Equal([]string{"web-02"}, target.FailedAgents())
+			},
+		},
+		{
+			name: "when poll returns error wraps it",
+			polls: []jobPoll{
+				{code: http.StatusInternalServerError},
+			},
+			id: waitJobA,
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobDetail],
+				err error,
+				_ []string,
+			) {
+				suite.Nil(resp)
+				suite.Contains(err.Error(), "poll job")
+
+				var target *osapi.ServerError
+				suite.True(errors.As(err, &target))
+			},
+		},
+		{
+			name: "when job ID is invalid returns error",
+			polls: []jobPoll{
+				{status: "completed"},
+			},
+			id: "not-a-uuid",
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobDetail],
+				err error,
+				_ []string,
+			) {
+				suite.Nil(resp)
+				suite.Contains(err.Error(), "invalid job ID")
+			},
+		},
+		{
+			name: "when context is canceled returns context error",
+			polls: []jobPoll{
+				{status: "processing"},
+			},
+			id:      waitJobA,
+			timeout: 30 * time.Millisecond,
+			validateFunc: func(
+				resp *osapi.Response[osapi.JobDetail],
+				err error,
+				_ []string,
+			) {
+				suite.Nil(resp)
+				suite.ErrorIs(err, context.DeadlineExceeded)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := jobPollServer(map[string][]jobPoll{tc.id: tc.polls})
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			ctx := suite.ctx
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			var progress []string
+
+			resp, err := sut.Job.Wait(
+				ctx,
+				tc.id,
+				osapi.WithPollInterval(time.Millisecond, 4*time.Millisecond),
+				osapi.WithAgentProgress(func(
+					_ string,
+					host string,
+					state osapi.AgentState,
+				) {
+					progress = append(progress, host+"="+state.Status)
+				}),
+			)
+			tc.validateFunc(resp, err, progress)
+		})
+	}
+}
+
+func (suite *JobWaitPublicTestSuite) TestWaitAll() {
+	tests := []struct {
+		name         string
+		polls        map[string][]jobPoll
+		validateFunc func([]osapi.JobDetail, error, int32)
+	}{
+		{
+			name: "when all jobs complete returns details in order",
+			polls: map[string][]jobPoll{
+				waitJobA: {{status: "processing"}, {status: "completed"}},
+				waitJobB: {{status: "completed"}},
+			},
+			validateFunc: func(jobs []osapi.JobDetail, err error, _ int32) {
+				suite.NoError(err)
+				suite.Require().Len(jobs, 2)
+				suite.Equal(waitJobA, jobs[0].ID)
+				suite.Equal(waitJobB, jobs[1].ID)
+			},
+		},
+		{
+			name: "when one job fails returns joined error and all details",
+			polls: map[string][]jobPoll{
+				waitJobA: {{status: "completed"}},
+				waitJobB: {{status: "failed", err: "boom", agentStates: map[string]any{
+					"web-01": map[string]any{"status": "failed"},
+				}}},
+			},
+			validateFunc: func(jobs []osapi.JobDetail, err error, progress int32) {
+				var target *osapi.JobFailedError
+				suite.Require().True(errors.As(err, &target))
+				suite.Equal(waitJobB, target.JobID)
+				suite.Equal("completed", jobs[0].Status)
+				suite.Equal("failed", jobs[1].Status)
+				suite.Equal(int32(1), progress)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := jobPollServer(tc.polls)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			var progress atomic.Int32
+
+			jobs, err := sut.Job.WaitAll(
+				suite.ctx,
+				[]string{waitJobA, waitJobB},
+				osapi.WithPollInterval(time.Millisecond, time.Millisecond),
+				osapi.WithAgentProgress(func(string, string, osapi.AgentState) {
+					progress.Add(1)
+				}),
+			)
+			tc.validateFunc(jobs, err, progress.Load())
+		})
+	}
+}
+
+func TestJobWaitPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(JobWaitPublicTestSuite))
+}
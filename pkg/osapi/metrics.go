@@ -32,11 +32,14 @@ import (
 
 // MetricsService provides Prometheus metrics access.
 type MetricsService struct {
-	client  *gen.ClientWithResponses
-	baseURL string
+	client     *gen.ClientWithResponses
+	httpClient *http.Client
+	baseURL    string
 }
 
 // Get fetches the raw Prometheus metrics text from the /metrics endpoint.
+// The request goes through the client's configured transport, so TLS,
+// auth, and logging settings apply.
 func (s *MetricsService) Get(
 	ctx context.Context,
 ) (string, error) {
@@ -47,7 +50,7 @@ func (s *MetricsService) Get(
 		return "", fmt.Errorf("creating metrics request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("fetching metrics: %w", err)
 	}
@@ -64,3 +67,37 @@ func (s *MetricsService) Get(
 
 	return string(body), nil
 }
+
+// Parse fetches the Prometheus metrics from the /metrics endpoint and
+// decodes them into typed metric families.
+func (s *MetricsService) Parse(
+	ctx context.Context,
+) (*MetricSet, error) {
+	text, err := s.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := ParseMetrics(strings.NewReader(text))
+	if err != nil {
+		return nil, fmt.Errorf("parsing metrics: %w", err)
+	}
+
+	return set, nil
+}
+
+// Query fetches and parses the Prometheus metrics, returning the samples
+// named name whose labels contain every key/value pair in labels. A nil
+// labels map matches all samples with that name.
+func (s *MetricsService) Query(
+	ctx context.Context,
+	name string,
+	labels map[string]string,
+) ([]Sample, error) {
+	set, err := s.Parse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return set.Query(name, labels), nil
+}
@@ -71,6 +71,18 @@ func (suite *MetricsPublicTestSuite) TestGet() {
 				suite.Equal("# HELP go_goroutines\n", body)
 			},
 		},
+		{
+			name: "when request goes through client transport sends auth header",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+			},
+			ctx: suite.ctx,
+			validateFunc: func(body string, err error) {
+				suite.NoError(err)
+				suite.Equal("Bearer test-token", body)
+			},
+		},
 		{
 			name: "when server returns non-200 returns error",
 			handler: func(w http.ResponseWriter, _ *http.Request) {
@@ -132,6 +144,115 @@ func (suite *MetricsPublicTestSuite) TestGet() {
 	}
 }
 
+func (suite *MetricsPublicTestSuite) TestParse() {
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		validateFunc func(*osapi.MetricSet, error)
+	}{
+		{
+			name: "when server returns metrics returns parsed families",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(
+					"# TYPE osapi_queue_depth gauge\nosapi_queue_depth 7\n",
+				))
+			},
+			validateFunc: func(set *osapi.MetricSet, err error) {
+				suite.NoError(err)
+				suite.Require().NotNil(set)
+				suite.Require().NotNil(set.Family("osapi_queue_depth"))
+				suite.Equal(osapi.MetricTypeGauge, set.Family("osapi_queue_depth").Type)
+			},
+		},
+		{
+			name: "when server returns non-200 returns error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			validateFunc: func(set *osapi.MetricSet, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "metrics endpoint returned status")
+				suite.Nil(set)
+			},
+		},
+		{
+			name: "when body is malformed returns error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("osapi_queue_depth seven\n"))
+			},
+			validateFunc: func(set *osapi.MetricSet, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "parsing metrics")
+				suite.Nil(set)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			set, err := sut.Metrics.Parse(suite.ctx)
+			tc.validateFunc(set, err)
+		})
+	}
+}
+
+func (suite *MetricsPublicTestSuite) TestQuery() {
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		validateFunc func([]osapi.Sample, error)
+	}{
+		{
+			name: "when samples match returns them",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(
+					"osapi_jobs_total{status=\"completed\"} 42\n" +
+						"osapi_jobs_total{status=\"failed\"} 3\n",
+				))
+			},
+			validateFunc: func(samples []osapi.Sample, err error) {
+				suite.NoError(err)
+				suite.Require().Len(samples, 1)
+				suite.Equal(3.0, samples[0].Value)
+			},
+		},
+		{
+			name: "when server returns non-200 returns error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			validateFunc: func(samples []osapi.Sample, err error) {
+				suite.Error(err)
+				suite.Nil(samples)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			sut := osapi.New(server.URL, "test-token")
+
+			samples, err := sut.Metrics.Query(
+				suite.ctx,
+				"osapi_jobs_total",
+				map[string]string{"status": "failed"},
+			)
+			tc.validateFunc(samples, err)
+		})
+	}
+}
+
 func TestMetricsPublicTestSuite(t *testing.T) {
 	suite.Run(t, new(MetricsPublicTestSuite))
 }
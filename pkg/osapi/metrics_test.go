@@ -73,13 +73,10 @@ func (s *MetricsTestSuite) TestGetReadBodyError() {
 			defer server.Close()
 
 			sut := &MetricsService{
-				baseURL: server.URL,
+				httpClient: &http.Client{Transport: &readErrorTransport{}},
+				baseURL:    server.URL,
 			}
 
-			origTransport := http.DefaultTransport
-			http.DefaultTransport = &readErrorTransport{}
-			defer func() { http.DefaultTransport = origTransport }()
-
 			body, err := sut.Get(context.Background())
 			tt.validateFunc(body, err)
 		})
@@ -0,0 +1,546 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetricType is the Prometheus metric type declared by a # TYPE line.
+type MetricType string
+
+// Metric types defined by the Prometheus text exposition format.
+const (
+	MetricTypeCounter   MetricType = "counter"
+	MetricTypeGauge     MetricType = "gauge"
+	MetricTypeHistogram MetricType = "histogram"
+	MetricTypeSummary   MetricType = "summary"
+	MetricTypeUntyped   MetricType = "untyped"
+)
+
+// Sample represents a single sample line from the exposition text.
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+	// Timestamp is the optional sample timestamp in milliseconds since
+	// the epoch. Zero when the line carries no timestamp.
+	Timestamp int64
+}
+
+// Bucket represents a cumulative histogram bucket.
+type Bucket struct {
+	UpperBound float64
+	Count      uint64
+}
+
+// Histogram represents a decoded histogram series.
+type Histogram struct {
+	Labels  map[string]string
+	Buckets []Bucket
+	Count   uint64
+	Sum     float64
+}
+
+// Quantile represents a single summary quantile.
+type Quantile struct {
+	Quantile float64
+	Value    float64
+}
+
+// Summary represents a decoded summary series.
+type Summary struct {
+	Labels    map[string]string
+	Quantiles []Quantile
+	Count     uint64
+	Sum       float64
+}
+
+// MetricFamily groups all samples sharing a metric name. Samples holds
+// every raw sample in the family; Histograms and Summaries hold the
+// typed view for histogram and summary families.
+type MetricFamily struct {
+	Name       string
+	Help       string
+	Type       MetricType
+	Samples    []Sample
+	Histograms []Histogram
+	Summaries  []Summary
+}
+
+// MetricSet is the parsed result of a Prometheus metrics scrape.
+type MetricSet struct {
+	Families []MetricFamily
+}
+
+// Family returns the metric family with the given name, or nil if
+// not found.
+func (m *MetricSet) Family(
+	name string,
+) *MetricFamily {
+	for i := range m.Families {
+		if m.Families[i].Name == name {
+			return &m.Families[i]
+		}
+	}
+
+	return nil
+}
+
+// Query returns the samples named name whose labels contain every
+// key/value pair in labels. Histogram and summary series can be
+// queried by their sample names (e.g., "foo_bucket", "foo_sum").
+func (m *MetricSet) Query(
+	name string,
+	labels map[string]string,
+) []Sample {
+	var samples []Sample
+
+	for _, f := range m.Families {
+		for _, s := range f.Samples {
+			if s.Name == name && labelsMatch(s.Labels, labels) {
+				samples = append(samples, s)
+			}
+		}
+	}
+
+	return samples
+}
+
+// ParseMetrics decodes Prometheus text exposition format into a
+// MetricSet.
+func ParseMetrics(
+	r io.Reader,
+) (*MetricSet, error) {
+	set := &MetricSet{}
+	index := make(map[string]int)
+
+	family := func(name string) *MetricFamily {
+		if i, ok := index[name]; ok {
+			return &set.Families[i]
+		}
+
+		index[name] = len(set.Families)
+		set.Families = append(set.Families, MetricFamily{
+			Name: name,
+			Type: MetricTypeUntyped,
+		})
+
+		return &set.Families[len(set.Families)-1]
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			if err := parseMetricComment(line, family); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+
+			continue
+		}
+
+		sample, err := parseSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		f := family(familyName(sample.Name, index, set.Families))
+		f.Samples = append(f.Samples, sample)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading metrics: %w", err)
+	}
+
+	for i := range set.Families {
+		f := &set.Families[i]
+
+		switch f.Type {
+		case MetricTypeHistogram:
+			f.Histograms = buildHistograms(f)
+		case MetricTypeSummary:
+			f.Summaries = buildSummaries(f)
+		}
+	}
+
+	return set, nil
+}
+
+// parseMetricComment handles # HELP and # TYPE lines. Other comments
+// are ignored.
+func parseMetricComment(
+	line string,
+	family func(string) *MetricFamily,
+) error {
+	fields := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, "#")), " ", 3)
+	if len(fields) < 2 {
+		return nil
+	}
+
+	switch fields[0] {
+	case "HELP":
+		help := ""
+		if len(fields) == 3 {
+			help = unescapeHelp(fields[2])
+		}
+
+		family(fields[1]).Help = help
+	case "TYPE":
+		if len(fields) < 3 {
+			return fmt.Errorf("missing type for metric %q", fields[1])
+		}
+
+		t := MetricType(strings.TrimSpace(fields[2]))
+		switch t {
+		case MetricTypeCounter,
+			MetricTypeGauge,
+			MetricTypeHistogram,
+			MetricTypeSummary,
+			MetricTypeUntyped:
+		default:
+			return fmt.Errorf("unknown metric type %q", t)
+		}
+
+		family(fields[1]).Type = t
+	}
+
+	return nil
+}
+
+// familyName resolves the family a sample belongs to, mapping the
+// _bucket, _sum, and _count series onto their histogram or summary.
+func familyName(
+	sampleName string,
+	index map[string]int,
+	families []MetricFamily,
+) string {
+	if _, ok := index[sampleName]; ok {
+		return sampleName
+	}
+
+	for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+		base, ok := strings.CutSuffix(sampleName, suffix)
+		if !ok {
+			continue
+		}
+
+		i, ok := index[base]
+		if !ok {
+			continue
+		}
+
+		switch families[i].Type {
+		case MetricTypeHistogram:
+			return base
+		case MetricTypeSummary:
+			if suffix != "_bucket" {
+				return base
+			}
+		}
+	}
+
+	return sampleName
+}
+
+// parseSample parses a sample line of the form
+// name{label="value",...} value [timestamp].
+func parseSample(
+	line string,
+) (Sample, error) {
+	s := Sample{}
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return s, fmt.Errorf("invalid sample %q", line)
+	}
+
+	s.Name = line[:end]
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		labels, n, err := parseLabels(rest)
+		if err != nil {
+			return s, err
+		}
+
+		s.Labels = labels
+		rest = rest[n:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return s, fmt.Errorf("invalid sample %q", line)
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return s, fmt.Errorf("invalid value for %s: %w", s.Name, err)
+	}
+
+	s.Value = value
+
+	if len(fields) == 2 {
+		ts, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid timestamp for %s: %w", s.Name, err)
+		}
+
+		s.Timestamp = ts
+	}
+
+	return s, nil
+}
+
+// parseLabels parses a {key="value",...} label set and returns the
+// labels and the number of bytes consumed.
+func parseLabels(
+	text string,
+) (map[string]string, int, error) {
+	labels := make(map[string]string)
+	i := 1
+
+	for {
+		for i < len(text) && (text[i] == ' ' || text[i] == ',') {
+			i++
+		}
+
+		if i >= len(text) {
+			return nil, 0, fmt.Errorf("unterminated label set")
+		}
+
+		if text[i] == '}' {
+			return labels, i + 1, nil
+		}
+
+		eq := strings.IndexByte(text[i:], '=')
+		if eq <= 0 {
+			return nil, 0, fmt.Errorf("invalid label at %q", text[i:])
+		}
+
+		key := strings.TrimSpace(text[i : i+eq])
+		i += eq + 1
+
+		if i >= len(text) || text[i] != '"' {
+			return nil, 0, fmt.Errorf("label %q value must be quoted", key)
+		}
+
+		i++
+
+		var value strings.Builder
+		closed := false
+
+		for i < len(text) {
+			c := text[i]
+			if c == '\\' && i+1 < len(text) {
+				switch text[i+1] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(text[i+1])
+				}
+
+				i += 2
+
+				continue
+			}
+
+			i++
+
+			if c == '"' {
+				closed = true
+
+				break
+			}
+
+			value.WriteByte(c)
+		}
+
+		if !closed {
+			return nil, 0, fmt.Errorf("unterminated value for label %q", key)
+		}
+
+		labels[key] = value.String()
+	}
+}
+
+// unescapeHelp reverses the escaping applied to HELP docstrings.
+func unescapeHelp(
+	s string,
+) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(s)
+}
+
+// labelsMatch returns true if have contains every pair in want.
+func labelsMatch(
+	have map[string]string,
+	want map[string]string,
+) bool {
+	for k, v := range want {
+		if have[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// seriesKey returns a stable identity for a label set, ignoring the
+// named label (le for histograms, quantile for summaries).
+func seriesKey(
+	labels map[string]string,
+	ignore string,
+) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if k != ignore {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%q,", k, labels[k])
+	}
+
+	return b.String()
+}
+
+// withoutLabel returns a copy of labels without the named label.
+func withoutLabel(
+	labels map[string]string,
+	name string,
+) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k != name {
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+// buildHistograms groups a histogram family's samples into series.
+func buildHistograms(
+	f *MetricFamily,
+) []Histogram {
+	var histograms []Histogram
+	index := make(map[string]int)
+
+	for _, s := range f.Samples {
+		key := seriesKey(s.Labels, "le")
+
+		i, ok := index[key]
+		if !ok {
+			i = len(histograms)
+			index[key] = i
+			histograms = append(histograms, Histogram{
+				Labels: withoutLabel(s.Labels, "le"),
+			})
+		}
+
+		h := &histograms[i]
+
+		switch s.Name {
+		case f.Name + "_bucket":
+			bound, err := strconv.ParseFloat(s.Labels["le"], 64)
+			if err != nil {
+				continue
+			}
+
+			h.Buckets = append(h.Buckets, Bucket{
+				UpperBound: bound,
+				Count:      uint64(s.Value),
+			})
+		case f.Name + "_sum":
+			h.Sum = s.Value
+		case f.Name + "_count":
+			h.Count = uint64(s.Value)
+		}
+	}
+
+	for i := range histograms {
+		sort.Slice(histograms[i].Buckets, func(a, b int) bool {
+			return histograms[i].Buckets[a].UpperBound < histograms[i].Buckets[b].UpperBound
+		})
+	}
+
+	return histograms
+}
+
+// buildSummaries groups a summary family's samples into series.
+func buildSummaries(
+	f *MetricFamily,
+) []Summary {
+	var summaries []Summary
+	index := make(map[string]int)
+
+	for _, s := range f.Samples {
+		key := seriesKey(s.Labels, "quantile")
+
+		i, ok := index[key]
+		if !ok {
+			i = len(summaries)
+			index[key] = i
+			summaries = append(summaries, Summary{
+				Labels: withoutLabel(s.Labels, "quantile"),
+			})
+		}
+
+		sum := &summaries[i]
+
+		switch s.Name {
+		case f.Name:
+			q, err := strconv.ParseFloat(s.Labels["quantile"], 64)
+			if err != nil {
+				continue
+			}
+
+			sum.Quantiles = append(sum.Quantiles, Quantile{
+				Quantile: q,
+				Value:    s.Value,
+			})
+		case f.Name + "_sum":
+			sum.Sum = s.Value
+		case f.Name + "_count":
+			sum.Count = uint64(s.Value)
+		}
+	}
+
+	return summaries
+}
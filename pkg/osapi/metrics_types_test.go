@@ -0,0 +1,277 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTypesTestSuite struct {
+	suite.Suite
+}
+
+func (suite *MetricsTypesTestSuite) TestParseMetrics() {
+	tests := []struct {
+		name         string
+		input        string
+		validateFunc func(*MetricSet, error)
+	}{
+		{
+			name: "when counter and gauge families are present",
+			input: `# HELP osapi_jobs_total Total jobs processed.
+# TYPE osapi_jobs_total counter
+osapi_jobs_total{status="completed"} 42
+osapi_jobs_total{status="failed"} 3 1700000000000
+# HELP osapi_queue_depth Current queue depth.
+# TYPE osapi_queue_depth gauge
+osapi_queue_depth 7
+`,
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Require().NoError(err)
+				suite.Len(set.Families, 2)
+
+				jobs := set.Family("osapi_jobs_total")
+				suite.Require().NotNil(jobs)
+				suite.Equal(MetricTypeCounter, jobs.Type)
+				suite.Equal("Total jobs processed.", jobs.Help)
+				suite.Len(jobs.Samples, 2)
+				suite.Equal(int64(1700000000000), jobs.Samples[1].Timestamp)
+
+				depth := set.Family("osapi_queue_depth")
+				suite.Require().NotNil(depth)
+				suite.Equal(MetricTypeGauge, depth.Type)
+				suite.Equal(7.0, depth.Samples[0].Value)
+			},
+		},
+		{
+			name: "when histogram family is present",
+			input: `# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{method="GET",le="0.1"} 5
+http_request_duration_seconds_bucket{method="GET",le="+Inf"} 8
+http_request_duration_seconds_bucket{method="GET",le="0.5"} 7
+http_request_duration_seconds_sum{method="GET"} 1.5
+http_request_duration_seconds_count{method="GET"} 8
+`,
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Require().NoError(err)
+				suite.Len(set.Families, 1)
+
+				f := set.Family("http_request_duration_seconds")
+				suite.Require().NotNil(f)
+				suite.Len(f.Samples, 5)
+				suite.Require().Len(f.Histograms, 1)
+
+				h := f.Histograms[0]
+				suite.Equal(map[string]string{"method": "GET"}, h.Labels)
+				suite.Equal(uint64(8), h.Count)
+				suite.Equal(1.5, h.Sum)
+				suite.Require().Len(h.Buckets, 3)
+				suite.Equal(0.1, h.Buckets[0].UpperBound)
+				suite.Equal(0.5, h.Buckets[1].UpperBound)
+				suite.True(math.IsInf(h.Buckets[2].UpperBound, 1))
+				suite.Equal(uint64(8), h.Buckets[2].Count)
+			},
+		},
+		{
+			name: "when summary family is present",
+			input: `# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 0.05
+rpc_duration_seconds{quantile="0.99"} 0.2
+rpc_duration_seconds_sum 12.5
+rpc_duration_seconds_count 100
+`,
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Require().NoError(err)
+
+				f := set.Family("rpc_duration_seconds")
+				suite.Require().NotNil(f)
+				suite.Require().Len(f.Summaries, 1)
+
+				sum := f.Summaries[0]
+				suite.Empty(sum.Labels)
+				suite.Equal(uint64(100), sum.Count)
+				suite.Equal(12.5, sum.Sum)
+				suite.Equal([]Quantile{
+					{Quantile: 0.5, Value: 0.05},
+					{Quantile: 0.99, Value: 0.2},
+				}, sum.Quantiles)
+			},
+		},
+		{
+			name: "when sample has no TYPE line it is untyped",
+			input: `# some free-form comment
+process_open_fds 12
+`,
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Require().NoError(err)
+
+				f := set.Family("process_open_fds")
+				suite.Require().NotNil(f)
+				suite.Equal(MetricTypeUntyped, f.Type)
+			},
+		},
+		{
+			name: "when label values and help contain escapes",
+			input: `# HELP escaped Line one\nline two \\ done.
+escaped{path="C:\\tmp",msg="say \"hi\"",multi="a\nb",} NaN
+`,
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Require().NoError(err)
+
+				f := set.Family("escaped")
+				suite.Require().NotNil(f)
+				suite.Equal("Line one\nline two \\ done.", f.Help)
+				suite.Equal(`C:\tmp`, f.Samples[0].Labels["path"])
+				suite.Equal(`say "hi"`, f.Samples[0].Labels["msg"])
+				suite.Equal("a\nb", f.Samples[0].Labels["multi"])
+				suite.True(math.IsNaN(f.Samples[0].Value))
+			},
+		},
+		{
+			name:  "when value is invalid returns error",
+			input: "bad_metric abc\n",
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "line 1")
+				suite.Contains(err.Error(), "invalid value")
+				suite.Nil(set)
+			},
+		},
+		{
+			name:  "when timestamp is invalid returns error",
+			input: "bad_metric 1 soon\n",
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid timestamp")
+				suite.Nil(set)
+			},
+		},
+		{
+			name:  "when label set is unterminated returns error",
+			input: `bad_metric{a="b" 1`,
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Error(err)
+				suite.Nil(set)
+			},
+		},
+		{
+			name:  "when label value is unquoted returns error",
+			input: "bad_metric{a=b} 1\n",
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "must be quoted")
+				suite.Nil(set)
+			},
+		},
+		{
+			name:  "when TYPE is unknown returns error",
+			input: "# TYPE foo widget\n",
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "unknown metric type")
+				suite.Nil(set)
+			},
+		},
+		{
+			name:  "when sample has no value returns error",
+			input: "lonely_metric\n",
+			validateFunc: func(set *MetricSet, err error) {
+				suite.Error(err)
+				suite.Contains(err.Error(), "invalid sample")
+				suite.Nil(set)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			set, err := ParseMetrics(strings.NewReader(tc.input))
+			tc.validateFunc(set, err)
+		})
+	}
+}
+
+func (suite *MetricsTypesTestSuite) TestQuery() {
+	input := `# TYPE osapi_jobs_total counter
+osapi_jobs_total{status="completed",queue="default"} 42
+osapi_jobs_total{status="failed",queue="default"} 3
+# TYPE latency histogram
+latency_bucket{le="1"} 2
+latency_bucket{le="+Inf"} 4
+latency_count 4
+`
+
+	set, err := ParseMetrics(strings.NewReader(input))
+	suite.Require().NoError(err)
+
+	tests := []struct {
+		name       string
+		metric     string
+		labels     map[string]string
+		wantValues []float64
+	}{
+		{
+			name:       "when labels are nil returns all samples",
+			metric:     "osapi_jobs_total",
+			wantValues: []float64{42, 3},
+		},
+		{
+			name:       "when labels match a subset returns matching samples",
+			metric:     "osapi_jobs_total",
+			labels:     map[string]string{"status": "failed"},
+			wantValues: []float64{3},
+		},
+		{
+			name:   "when labels do not match returns nothing",
+			metric: "osapi_jobs_total",
+			labels: map[string]string{"status": "pending"},
+		},
+		{
+			name:       "when querying histogram sample names",
+			metric:     "latency_bucket",
+			labels:     map[string]string{"le": "1"},
+			wantValues: []float64{2},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			samples := set.Query(tc.metric, tc.labels)
+
+			var values []float64
+			for _, s := range samples {
+				values = append(values, s.Value)
+			}
+
+			suite.Equal(tc.wantValues, values)
+		})
+	}
+
+	suite.Nil(set.Family("missing"))
+}
+
+func TestMetricsTypesTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTypesTestSuite))
+}
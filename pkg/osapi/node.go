@@ -29,7 +29,9 @@ import (
 
 // NodeService provides node management operations.
 type NodeService struct {
-	client *gen.ClientWithResponses
+	client           *gen.ClientWithResponses
+	strictHostErrors bool
+	cache            *responseCache
 }
 
 // ExecRequest contains parameters for direct command execution.
@@ -101,6 +103,11 @@ func (s *NodeService) Status(
 	ctx context.Context,
 	target string,
 ) (*Response[Collection[NodeStatus]], error) {
+	key := cacheKey{op: "node.status", target: target}
+	if result, ok := cacheLookup[Collection[NodeStatus]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeStatusWithResponse(ctx, target)
 	if err != nil {
 		return nil, fmt.Errorf("get status: %w", err)
@@ -117,7 +124,10 @@ func (s *NodeService) Status(
 		}}
 	}
 
-	return NewResponse(nodeStatusCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, nodeStatusCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // Hostname retrieves the hostname from the target host.
@@ -125,6 +135,11 @@ func (s *NodeService) Hostname(
 	ctx context.Context,
 	target string,
 ) (*Response[Collection[HostnameResult]], error) {
+	key := cacheKey{op: "node.hostname", target: target}
+	if result, ok := cacheLookup[Collection[HostnameResult]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeHostnameWithResponse(ctx, target)
 	if err != nil {
 		return nil, fmt.Errorf("get hostname: %w", err)
@@ -141,7 +156,10 @@ func (s *NodeService) Hostname(
 		}}
 	}
 
-	return NewResponse(hostnameCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, hostnameCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // Disk retrieves disk usage information from the target host.
@@ -149,6 +167,11 @@ func (s *NodeService) Disk(
 	ctx context.Context,
 	target string,
 ) (*Response[Collection[DiskResult]], error) {
+	key := cacheKey{op: "node.disk", target: target}
+	if result, ok := cacheLookup[Collection[DiskResult]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeDiskWithResponse(ctx, target)
 	if err != nil {
 		return nil, fmt.Errorf("get disk: %w", err)
@@ -165,7 +188,10 @@ func (s *NodeService) Disk(
 		}}
 	}
 
-	return NewResponse(diskCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, diskCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // Memory retrieves memory usage information from the target host.
@@ -173,6 +199,11 @@ func (s *NodeService) Memory(
 	ctx context.Context,
 	target string,
 ) (*Response[Collection[MemoryResult]], error) {
+	key := cacheKey{op: "node.memory", target: target}
+	if result, ok := cacheLookup[Collection[MemoryResult]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeMemoryWithResponse(ctx, target)
 	if err != nil {
 		return nil, fmt.Errorf("get memory: %w", err)
@@ -189,7 +220,10 @@ func (s *NodeService) Memory(
 		}}
 	}
 
-	return NewResponse(memoryCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, memoryCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // Load retrieves load average information from the target host.
@@ -197,6 +231,11 @@ func (s *NodeService) Load(
 	ctx context.Context,
 	target string,
 ) (*Response[Collection[LoadResult]], error) {
+	key := cacheKey{op: "node.load", target: target}
+	if result, ok := cacheLookup[Collection[LoadResult]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeLoadWithResponse(ctx, target)
 	if err != nil {
 		return nil, fmt.Errorf("get load: %w", err)
@@ -213,7 +252,10 @@ func (s *NodeService) Load(
 		}}
 	}
 
-	return NewResponse(loadCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, loadCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // OS retrieves operating system information from the target host.
@@ -221,6 +263,11 @@ func (s *NodeService) OS(
 	ctx context.Context,
 	target string,
 ) (*Response[Collection[OSInfoResult]], error) {
+	key := cacheKey{op: "node.os", target: target}
+	if result, ok := cacheLookup[Collection[OSInfoResult]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeOSWithResponse(ctx, target)
 	if err != nil {
 		return nil, fmt.Errorf("get os: %w", err)
@@ -237,7 +284,10 @@ func (s *NodeService) OS(
 		}}
 	}
 
-	return NewResponse(osInfoCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, osInfoCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // Uptime retrieves uptime information from the target host.
@@ -245,6 +295,11 @@ func (s *NodeService) Uptime(
 	ctx context.Context,
 	target string,
 ) (*Response[Collection[UptimeResult]], error) {
+	key := cacheKey{op: "node.uptime", target: target}
+	if result, ok := cacheLookup[Collection[UptimeResult]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeUptimeWithResponse(ctx, target)
 	if err != nil {
 		return nil, fmt.Errorf("get uptime: %w", err)
@@ -261,7 +316,10 @@ func (s *NodeService) Uptime(
 		}}
 	}
 
-	return NewResponse(uptimeCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, uptimeCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // GetDNS retrieves DNS configuration for a network interface on the
@@ -271,6 +329,11 @@ func (s *NodeService) GetDNS(
 	target string,
 	interfaceName string,
 ) (*Response[Collection[DNSConfig]], error) {
+	key := cacheKey{op: "node.dns", target: target, arg: interfaceName}
+	if result, ok := cacheLookup[Collection[DNSConfig]](s.cache, key); ok {
+		return result, nil
+	}
+
 	resp, err := s.client.GetNodeNetworkDNSByInterfaceWithResponse(ctx, target, interfaceName)
 	if err != nil {
 		return nil, fmt.Errorf("get dns: %w", err)
@@ -287,7 +350,10 @@ func (s *NodeService) GetDNS(
 		}}
 	}
 
-	return NewResponse(dnsConfigCollectionFromGen(resp.JSON200), resp.Body), nil
+	result, err := collectionResponse(s.strictHostErrors, dnsConfigCollectionFromGen(resp.JSON200), resp.Body)
+	s.cache.store(key, result, err)
+
+	return result, err
 }
 
 // UpdateDNS updates DNS configuration for a network interface on the
@@ -299,6 +365,8 @@ func (s *NodeService) UpdateDNS(
 	servers []string,
 	searchDomains []string,
 ) (*Response[Collection[DNSUpdateResult]], error) {
+	defer s.cache.invalidate(target)
+
 	body := gen.DNSConfigUpdateRequest{
 		InterfaceName: interfaceName,
 	}
@@ -327,7 +395,7 @@ func (s *NodeService) UpdateDNS(
 		}}
 	}
 
-	return NewResponse(dnsUpdateCollectionFromGen(resp.JSON202), resp.Body), nil
+	return collectionResponse(s.strictHostErrors, dnsUpdateCollectionFromGen(resp.JSON202), resp.Body)
 }
 
 // Ping sends an ICMP ping to the specified address from the target host.
@@ -356,7 +424,7 @@ func (s *NodeService) Ping(
 		}}
 	}
 
-	return NewResponse(pingCollectionFromGen(resp.JSON200), resp.Body), nil
+	return collectionResponse(s.strictHostErrors, pingCollectionFromGen(resp.JSON200), resp.Body)
 }
 
 // Exec executes a command directly without a shell interpreter.
@@ -364,6 +432,8 @@ func (s *NodeService) Exec(
 	ctx context.Context,
 	req ExecRequest,
 ) (*Response[Collection[CommandResult]], error) {
+	defer s.cache.invalidate(req.Target)
+
 	body := gen.CommandExecRequest{
 		Command: req.Command,
 	}
@@ -396,7 +466,7 @@ func (s *NodeService) Exec(
 		}}
 	}
 
-	return NewResponse(commandCollectionFromGen(resp.JSON202), resp.Body), nil
+	return collectionResponse(s.strictHostErrors, commandCollectionFromGen(resp.JSON202), resp.Body)
 }
 
 // Shell executes a command through /bin/sh -c with shell features
@@ -405,6 +475,8 @@ func (s *NodeService) Shell(
 	ctx context.Context,
 	req ShellRequest,
 ) (*Response[Collection[CommandResult]], error) {
+	defer s.cache.invalidate(req.Target)
+
 	body := gen.CommandShellRequest{
 		Command: req.Command,
 	}
@@ -433,7 +505,7 @@ func (s *NodeService) Shell(
 		}}
 	}
 
-	return NewResponse(commandCollectionFromGen(resp.JSON202), resp.Body), nil
+	return collectionResponse(s.strictHostErrors, commandCollectionFromGen(resp.JSON202), resp.Body)
 }
 
 // FileDeploy deploys a file from the Object Store to the target host.
@@ -441,6 +513,8 @@ func (s *NodeService) FileDeploy(
 	ctx context.Context,
 	req FileDeployOpts,
 ) (*Response[FileDeployResult], error) {
+	defer s.cache.invalidate(req.Target)
+
 	body := gen.FileDeployRequest{
 		ObjectName:  req.ObjectName,
 		Path:        req.Path,
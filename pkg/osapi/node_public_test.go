@@ -339,6 +339,70 @@ func (suite *NodePublicTestSuite) TestDisk() {
 	}
 }
 
+func (suite *NodePublicTestSuite) TestStrictHostErrors() {
+	tests := []struct {
+		name         string
+		opts         []osapi.Option
+		body         string
+		validateFunc func(*osapi.Response[osapi.Collection[osapi.DiskResult]], error)
+	}{
+		{
+			name: "when strict and a host failed returns response and MultiHostError",
+			opts: []osapi.Option{osapi.WithStrictHostErrors()},
+			body: `{"results":[{"hostname":"web-01"},{"hostname":"web-02","error":"permission denied"}]}`,
+			validateFunc: func(resp *osapi.Response[osapi.Collection[osapi.DiskResult]], err error) {
+				suite.Require().NotNil(resp)
+				suite.Len(resp.Data.Results, 2)
+
+				var multi *osapi.MultiHostError
+				suite.Require().True(errors.As(err, &multi))
+				suite.Equal([]string{"web-02"}, multi.Hosts())
+
+				var agentErr *osapi.AgentError
+				suite.Require().True(errors.As(err, &agentErr))
+				suite.Equal("permission denied", agentErr.Message)
+			},
+		},
+		{
+			name: "when strict and no host failed returns nil error",
+			opts: []osapi.Option{osapi.WithStrictHostErrors()},
+			body: `{"results":[{"hostname":"web-01"}]}`,
+			validateFunc: func(resp *osapi.Response[osapi.Collection[osapi.DiskResult]], err error) {
+				suite.NoError(err)
+				suite.NotNil(resp)
+			},
+		},
+		{
+			name: "when not strict and a host failed returns nil error",
+			body: `{"results":[{"hostname":"web-02","error":"permission denied"}]}`,
+			validateFunc: func(resp *osapi.Response[osapi.Collection[osapi.DiskResult]], err error) {
+				suite.NoError(err)
+				suite.Require().NotNil(resp)
+				suite.Error(resp.Data.Err())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(tc.body))
+				}),
+			)
+			defer server.Close()
+
+			opts := append([]osapi.Option{osapi.WithLogger(slog.Default())}, tc.opts...)
+			sut := osapi.New(server.URL, "test-token", opts...)
+
+			resp, err := sut.Node.Disk(suite.ctx, "_all")
+			tc.validateFunc(resp, err)
+		})
+	}
+}
+
 func (suite *NodePublicTestSuite) TestMemory() {
 	tests := []struct {
 		name         string
@@ -44,32 +44,38 @@ import (
 
 // Client is the top-level OSAPI SDK client. Use New() to create one.
 type Client struct {
-	// Agent provides agent discovery and details operations.
+	// Agent provides agent discovery, details, drain, and watch
+	// operations.
 	Agent *AgentService
 
 	// Node provides node management operations (hostname, status, disk,
 	// memory, load, OS, uptime, network DNS/ping, command exec/shell).
 	Node *NodeService
 
-	// Job provides job queue operations (create, get, list, delete, retry).
+	// Job provides job queue operations (create, submit, get, list with
+	// filters, delete, retry, wait, queue stats, and bulk operations).
 	Job *JobService
 
 	// Health provides health check operations (liveness, readiness, status).
 	Health *HealthService
 
-	// Audit provides audit log operations (list, get, export).
+	// Audit provides audit log operations (list, get, query, export).
 	Audit *AuditService
 
 	// Metrics provides Prometheus metrics access.
 	Metrics *MetricsService
 
-	// File provides file management operations (upload, list, get, delete).
+	// File provides file management operations (upload, list, get, delete,
+	// sync, versions, and drift).
 	File *FileService
 
-	httpClient    *gen.ClientWithResponses
-	baseURL       string
-	logger        *slog.Logger
-	baseTransport http.RoundTripper
+	httpClient       *gen.ClientWithResponses
+	baseURL          string
+	logger           *slog.Logger
+	baseTransport    http.RoundTripper
+	strictHostErrors bool
+	limiter          *rateLimiter
+	cache            *responseCache
 }
 
 // Option configures the Client.
@@ -93,6 +99,15 @@ func WithHTTPTransport(
 	}
 }
 
+// WithStrictHostErrors makes NodeService collection methods return a
+// *MultiHostError when any host in the response reported an error. The
+// response is still returned alongside the error.
+func WithStrictHostErrors() Option {
+	return func(c *Client) {
+		c.strictHostErrors = true
+	}
+}
+
 // New creates an OSAPI SDK client.
 func New(
 	baseURL string,
@@ -115,8 +130,18 @@ func New(
 		logger:     c.logger,
 	}
 
+	var rt http.RoundTripper = transport
+	if c.limiter != nil {
+		c.limiter.basePath = basePathOf(baseURL)
+		rt = &rateLimitTransport{
+			base:    transport,
+			limiter: c.limiter,
+			logger:  c.logger,
+		}
+	}
+
 	hc := &http.Client{
-		Transport: transport,
+		Transport: rt,
 	}
 
 	// Error is unreachable: the only ClientOption passed (WithHTTPClient) cannot
@@ -125,16 +150,26 @@ func New(
 	httpClient, _ := gen.NewClientWithResponses(baseURL, gen.WithHTTPClient(hc))
 
 	c.httpClient = httpClient
-	c.Agent = &AgentService{client: httpClient}
-	c.Node = &NodeService{client: httpClient}
-	c.Job = &JobService{client: httpClient}
+	if c.cache != nil {
+		c.cache.logger = c.logger
+	}
+
+	c.Job = &JobService{client: httpClient, cache: c.cache}
+	c.Agent = &AgentService{client: httpClient, jobs: c.Job, cache: c.cache}
+	c.Job.agents = c.Agent
+	c.Node = &NodeService{
+		client:           httpClient,
+		strictHostErrors: c.strictHostErrors,
+		cache:            c.cache,
+	}
 	c.Health = &HealthService{client: httpClient}
 	c.Audit = &AuditService{client: httpClient}
 	c.Metrics = &MetricsService{
-		client:  httpClient,
-		baseURL: baseURL,
+		client:     httpClient,
+		httpClient: hc,
+		baseURL:    baseURL,
 	}
-	c.File = &FileService{client: httpClient}
+	c.File = &FileService{client: httpClient, node: c.Node, agents: c.Agent}
 
 	return c
 }
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package osapi
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the number of items requested per page by the
+// auto-paginating iterators.
+const DefaultPageSize = 100
+
+// PageOption configures auto-paginating iterators.
+type PageOption func(*pageOptions)
+
+type pageOptions struct {
+	pageSize int
+	prefetch bool
+}
+
+// WithPageSize sets the number of items requested per page. Values
+// less than one use DefaultPageSize.
+func WithPageSize(
+	n int,
+) PageOption {
+	return func(o *pageOptions) { o.pageSize = n }
+}
+
+// WithPrefetch fetches the next page in the background while the
+// current page is being consumed.
+func WithPrefetch() PageOption {
+	return func(o *pageOptions) { o.prefetch = true }
+}
+
+// pageFetcher retrieves one page of items starting at offset. total
+// is the server-reported number of items, or zero when unknown.
+type pageFetcher[T any] func(
+	ctx context.Context,
+	limit int,
+	offset int,
+) (items []T, total int, err error)
+
+// page is the outcome of a single pageFetcher call.
+type page[T any] struct {
+	items []T
+	total int
+	err   error
+}
+
+// paginate returns an iterator that walks every page produced by
+// fetch, starting at offset. A non-zero maxItems caps the number of
+// items yielded. Errors are yielded once and end the iteration.
+func paginate[T any](
+	ctx context.Context,
+	fetch pageFetcher[T],
+	offset int,
+	maxItems int,
+	opts ...PageOption,
+) iter.Seq2[T, error] {
+	options := pageOptions{pageSize: DefaultPageSize}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	if options.pageSize < 1 {
+		options.pageSize = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		load := func(off int) <-chan page[T] {
+			ch := make(chan page[T], 1)
+			get := func() {
+				items, total, err := fetch(ctx, options.pageSize, off)
+				ch <- page[T]{items: items, total: total, err: err}
+			}
+
+			if options.prefetch {
+				go get()
+			} else {
+				get()
+			}
+
+			return ch
+		}
+
+		var zero T
+		yielded := 0
+		pending := load(offset)
+
+		for {
+			p := <-pending
+			if p.err == nil {
+				p.err = ctx.Err()
+			}
+
+			if p.err != nil {
+				yield(zero, p.err)
+
+				return
+			}
+
+			offset += len(p.items)
+
+			more := len(p.items) > 0
+			if p.total > 0 {
+				more = more && offset < p.total
+			} else {
+				more = more && len(p.items) >= options.pageSize
+			}
+
+			if maxItems > 0 && yielded+len(p.items) >= maxItems {
+				more = false
+			}
+
+			if more && options.prefetch {
+				pending = load(offset)
+			}
+
+			for _, item := range p.items {
+				if maxItems > 0 && yielded >= maxItems {
+					return
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+
+				yielded++
+			}
+
+			if !more {
+				return
+			}
+
+			if !options.prefetch {
+				pending = load(offset)
+			}
+		}
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// throttleFloor is the lowest fraction of its configured rate a bucket
+// slows down to after repeated 429/503 responses.
+const throttleFloor = 1.0 / 16
+
+// RateLimitOption configures WithRateLimit.
+type RateLimitOption func(*rateLimiter)
+
+// WithEndpointLimit adds a dedicated bucket for requests matching
+// pattern. A pattern is an optional method followed by a path, e.g.
+// "POST /job" or "GET /node/*/disk". A "*" segment matches any single
+// path segment, and a pattern also matches every path below it. When
+// several patterns match, the one with the most segments wins, and a
+// pattern with a method beats one without.
+func WithEndpointLimit(
+	pattern string,
+	rps float64,
+	burst int,
+) RateLimitOption {
+	return func(l *rateLimiter) {
+		l.buckets = append(l.buckets, newBucket(pattern, rps, burst))
+	}
+}
+
+// WithRateLimit limits the client to rps requests per second with
+// bursts of up to burst requests. Requests that do not match an
+// endpoint bucket share the default bucket. A 429 or 503 response halves
+// the rate of the bucket that sent the request and honors Retry-After;
+// each later successful response restores a tenth of the configured rate.
+// A non-positive rps leaves the bucket unlimited apart from Retry-After.
+func WithRateLimit(
+	rps float64,
+	burst int,
+	opts ...RateLimitOption,
+) Option {
+	return func(c *Client) {
+		l := &rateLimiter{
+			fallback: newBucket("*", rps, burst),
+		}
+
+		for _, opt := range opts {
+			opt(l)
+		}
+
+		sortBuckets(l.buckets)
+		c.limiter = l
+	}
+}
+
+// RateLimitStats reports the activity of a single rate limit bucket.
+type RateLimitStats struct {
+	// Endpoint is the bucket pattern, or "*" for the default bucket.
+	Endpoint string
+	// Rate is the current requests per second, lowered after throttling.
+	Rate float64
+	// Requests is the number of requests sent through the bucket.
+	Requests int64
+	// Delayed is the number of requests that had to wait.
+	Delayed int64
+	// Throttled is the number of 429/503 responses received.
+	Throttled int64
+	// Wait is the total time requests spent waiting.
+	Wait time.Duration
+	// MaxWait is the longest single wait.
+	MaxWait time.Duration
+}
+
+// RateLimitStats returns the statistics of every rate limit bucket,
+// endpoint buckets first and the default bucket last. It returns nil
+// when the client was created without WithRateLimit.
+func (c *Client) RateLimitStats() []RateLimitStats {
+	if c.limiter == nil {
+		return nil
+	}
+
+	stats := make([]RateLimitStats, 0, len(c.limiter.buckets)+1)
+	for _, b := range c.limiter.buckets {
+		stats = append(stats, b.snapshot())
+	}
+
+	return append(stats, c.limiter.fallback.snapshot())
+}
+
+type rateLimiter struct {
+	basePath string
+	buckets  []*bucket
+	fallback *bucket
+}
+
+// sortBuckets orders buckets from most to least specific, keeping
+// the order in which equally specific buckets were added.
+func sortBuckets(
+	buckets []*bucket,
+) {
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return buckets[i].specificity() > buckets[j].specificity()
+	})
+}
+
+// bucketFor returns the most specific bucket matching the request.
+func (l *rateLimiter) bucketFor(
+	req *http.Request,
+) *bucket {
+	path := strings.TrimPrefix(req.URL.Path, l.basePath)
+	segments := splitPath(path)
+
+	for _, b := range l.buckets {
+		if b.matches(req.Method, segments) {
+			return b
+		}
+	}
+
+	return l.fallback
+}
+
+type bucket struct {
+	pattern  string
+	method   string
+	segments []string
+
+	mu           sync.Mutex
+	limit        float64
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	stats        RateLimitStats
+}
+
+func newBucket(
+	pattern string,
+	rps float64,
+	burst int,
+) *bucket {
+	burst = max(burst, 1)
+
+	b := &bucket{
+		pattern: pattern,
+		limit:   rps,
+		rate:    rps,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+
+	b.method = strings.ToUpper(method)
+	b.segments = splitPath(path)
+
+	return b
+}
+
+func splitPath(
+	path string,
+) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
+
+func (b *bucket) specificity() int {
+	n := len(b.segments) * 2
+	if b.method != "" {
+		n++
+	}
+
+	return n
+}
+
+func (b *bucket) matches(
+	method string,
+	segments []string,
+) bool {
+	if b.method != "" && b.method != method {
+		return false
+	}
+
+	if len(segments) < len(b.segments) {
+		return false
+	}
+
+	for i, seg := range b.segments {
+		if seg != "*" && seg != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// refill adds the tokens earned since the last call. Callers must hold
+// b.mu.
+func (b *bucket) refill(
+	now time.Time,
+) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait
+// before sending the request.
+func (b *bucket) reserve(
+	now time.Time,
+) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var wait time.Duration
+	if b.rate > 0 {
+		b.refill(now)
+		b.tokens--
+
+		if b.tokens < 0 {
+			wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+		}
+	}
+
+	if blocked := b.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+
+	b.stats.Requests++
+	if wait > 0 {
+		b.stats.Delayed++
+		b.stats.Wait += wait
+		b.stats.MaxWait = max(b.stats.MaxWait, wait)
+	}
+
+	return wait
+}
+
+// cancel returns a reserved token when the request is abandoned.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate > 0 {
+		b.tokens = min(b.burst, b.tokens+1)
+	}
+}
+
+// throttle halves the rate, down to throttleFloor of the configured
+// limit, and blocks the bucket for retryAfter.
+func (b *bucket) throttle(
+	now time.Time,
+	retryAfter time.Duration,
+) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate > 0 {
+		b.refill(now)
+		b.rate = max(b.rate/2, b.limit*throttleFloor)
+	}
+
+	if until := now.Add(retryAfter); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+
+	b.stats.Throttled++
+}
+
+// restore raises a throttled rate by a tenth of the configured limit.
+func (b *bucket) restore(
+	now time.Time,
+) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate > 0 && b.rate < b.limit {
+		b.refill(now)
+		b.rate = min(b.limit, b.rate+b.limit/10)
+	}
+}
+
+func (b *bucket) snapshot() RateLimitStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := b.stats
+	stats.Endpoint = b.pattern
+	stats.Rate = b.rate
+
+	return stats
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an
+// HTTP date. It returns zero when the header is missing or invalid.
+func retryAfter(
+	header http.Header,
+	now time.Time,
+) time.Duration {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+
+	return 0
+}
+
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+	logger  *slog.Logger
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *rateLimitTransport) RoundTrip(
+	req *http.Request,
+) (*http.Response, error) {
+	b := t.limiter.bucketFor(req)
+
+	if wait := b.reserve(time.Now()); wait > 0 {
+		t.logger.Debug("rate limit wait",
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.String("endpoint", b.pattern),
+			slog.Duration("wait", wait),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			b.cancel()
+
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		delay := retryAfter(resp.Header, now)
+		b.throttle(now, delay)
+
+		t.logger.Debug("rate limit throttled",
+			slog.String("endpoint", b.pattern),
+			slog.Int("status", resp.StatusCode),
+			slog.Duration("retry_after", delay),
+		)
+	default:
+		b.restore(now)
+	}
+
+	return resp, nil
+}
+
+// basePathOf returns the path component of baseURL without a trailing
+// slash, so request paths can be matched relative to the API root.
+func basePathOf(
+	baseURL string,
+) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(u.Path, "/")
+}
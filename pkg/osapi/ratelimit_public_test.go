@@ -0,0 +1,157 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type RateLimitPublicTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+}
+
+func (suite *RateLimitPublicTestSuite) SetupTest() {
+	suite.ctx = context.Background()
+}
+
+func (suite *RateLimitPublicTestSuite) TestWithRateLimit() {
+	tests := []struct {
+		name         string
+		status       int
+		retryAfter   string
+		opts         []osapi.RateLimitOption
+		calls        int
+		timeout      time.Duration
+		validateFunc func([]osapi.RateLimitStats, time.Duration, error, int32)
+	}{
+		{
+			name:   "when requests exceed the burst delays them",
+			status: http.StatusOK,
+			calls:  3,
+			validateFunc: func(stats []osapi.RateLimitStats, elapsed time.Duration, _ error, _ int32) {
+				suite.Require().Len(stats, 1)
+				suite.Equal("*", stats[0].Endpoint)
+				suite.Equal(int64(3), stats[0].Requests)
+				suite.Equal(int64(2), stats[0].Delayed)
+				suite.Positive(stats[0].Wait)
+				suite.GreaterOrEqual(elapsed, 80*time.Millisecond)
+			},
+		},
+		{
+			name:   "when an endpoint bucket matches uses it",
+			status: http.StatusOK,
+			opts: []osapi.RateLimitOption{
+				osapi.WithEndpointLimit("GET /health", 1000, 10),
+			},
+			calls: 3,
+			validateFunc: func(stats []osapi.RateLimitStats, _ time.Duration, _ error, _ int32) {
+				suite.Require().Len(stats, 2)
+				suite.Equal("GET /health", stats[0].Endpoint)
+				suite.Equal(int64(3), stats[0].Requests)
+				suite.Zero(stats[0].Delayed)
+				suite.Zero(stats[1].Requests)
+			},
+		},
+		{
+			name:   "when server returns 429 slows down",
+			status: http.StatusTooManyRequests,
+			calls:  1,
+			validateFunc: func(stats []osapi.RateLimitStats, _ time.Duration, _ error, _ int32) {
+				suite.Require().Len(stats, 1)
+				suite.Equal(int64(1), stats[0].Throttled)
+				suite.InDelta(10, stats[0].Rate, 0.001)
+			},
+		},
+		{
+			name:       "when context ends during a server pause returns context error",
+			status:     http.StatusServiceUnavailable,
+			retryAfter: "60",
+			calls:      2,
+			timeout:    50 * time.Millisecond,
+			validateFunc: func(_ []osapi.RateLimitStats, _ time.Duration, err error, hits int32) {
+				suite.ErrorIs(err, context.DeadlineExceeded)
+				suite.Equal(int32(1), hits)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			var hits atomic.Int32
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					hits.Add(1)
+					if tc.retryAfter != "" {
+						w.Header().Set("Retry-After", tc.retryAfter)
+					}
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tc.status)
+					_, _ = w.Write([]byte(`{"status":"ok"}`))
+				}),
+			)
+			defer server.Close()
+
+			sut := osapi.New(
+				server.URL,
+				"test-token",
+				osapi.WithLogger(slog.Default()),
+				osapi.WithRateLimit(20, 1, tc.opts...),
+			)
+
+			ctx := suite.ctx
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			var err error
+
+			start := time.Now()
+			for range tc.calls {
+				_, err = sut.Health.Liveness(ctx)
+			}
+
+			tc.validateFunc(sut.RateLimitStats(), time.Since(start), err, hits.Load())
+		})
+	}
+}
+
+func (suite *RateLimitPublicTestSuite) TestRateLimitStatsWithoutLimit() {
+	sut := osapi.New("http://127.0.0.1:0", "test-token")
+
+	suite.Nil(sut.RateLimitStats())
+}
+
+func TestRateLimitPublicTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitPublicTestSuite))
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+func (s *RateLimitTestSuite) TestBucketFor() {
+	l := &rateLimiter{fallback: newBucket("*", 10, 1)}
+	WithEndpointLimit("/node/*/disk", 5, 1)(l)
+	WithEndpointLimit("POST /job", 1, 1)(l)
+	WithEndpointLimit("/job", 20, 1)(l)
+
+	sortBuckets(l.buckets)
+	l.basePath = "/api"
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected string
+	}{
+		{
+			name:     "when method and path match uses method bucket",
+			method:   http.MethodPost,
+			path:     "/api/job",
+			expected: "POST /job",
+		},
+		{
+			name:     "when nested path matches uses method bucket",
+			method:   http.MethodPost,
+			path:     "/api/job/abc/retry",
+			expected: "POST /job",
+		},
+		{
+			name:     "when method differs uses path bucket",
+			method:   http.MethodGet,
+			path:     "/api/job/abc",
+			expected: "/job",
+		},
+		{
+			name:     "when wildcard segment matches uses wildcard bucket",
+			method:   http.MethodGet,
+			path:     "/api/node/web-01/disk",
+			expected: "/node/*/disk",
+		},
+		{
+			name:     "when nothing matches uses default bucket",
+			method:   http.MethodGet,
+			path:     "/api/node/web-01/load",
+			expected: "*",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			req, err := http.NewRequest(tt.method, "http://example.com"+tt.path, nil)
+			s.Require().NoError(err)
+
+			s.Equal(tt.expected, l.bucketFor(req).pattern)
+		})
+	}
+}
+
+func (s *RateLimitTestSuite) TestBucketReserve() {
+	start := time.Now()
+	b := newBucket("*", 10, 2)
+	b.last = start
+
+	s.Zero(b.reserve(start))
+	s.Zero(b.reserve(start))
+	s.Equal(100*time.Millisecond, b.reserve(start))
+
+	// After 300ms the debt is repaid and two tokens are earned back.
+	s.Zero(b.reserve(start.Add(300 * time.Millisecond)))
+
+	b.cancel()
+	stats := b.snapshot()
+	s.Equal(int64(4), stats.Requests)
+	s.Equal(int64(1), stats.Delayed)
+	s.Equal(100*time.Millisecond, stats.MaxWait)
+}
+
+func (s *RateLimitTestSuite) TestBucketThrottle() {
+	start := time.Now()
+	b := newBucket("*", 16, 1)
+	b.last = start
+
+	b.throttle(start, 2*time.Second)
+	s.InDelta(8, b.snapshot().Rate, 0.001)
+	s.Equal(2*time.Second, b.reserve(start))
+
+	for range 10 {
+		b.throttle(start, 0)
+	}
+	s.InDelta(1, b.snapshot().Rate, 0.001)
+
+	for range 20 {
+		b.restore(start)
+	}
+	s.InDelta(16, b.snapshot().Rate, 0.001)
+	s.Equal(int64(11), b.snapshot().Throttled)
+}
+
+func (s *RateLimitTestSuite) TestUnlimitedBucket() {
+	start := time.Now()
+	b := newBucket("*", 0, 0)
+
+	for range 5 {
+		s.Zero(b.reserve(start))
+	}
+
+	b.throttle(start, time.Second)
+	s.Equal(time.Second, b.reserve(start))
+}
+
+func (s *RateLimitTestSuite) TestRetryAfter() {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{
+			name:     "when header is missing returns zero",
+			expected: 0,
+		},
+		{
+			name:     "when header is seconds returns duration",
+			value:    "3",
+			expected: 3 * time.Second,
+		},
+		{
+			name:     "when header is an HTTP date returns time until",
+			value:    now.Add(5 * time.Second).Format(http.TimeFormat),
+			expected: 5 * time.Second,
+		},
+		{
+			name:     "when header is invalid returns zero",
+			value:    "soon",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+
+			s.Equal(tt.expected, retryAfter(header, now))
+		})
+	}
+}
+
+func (s *RateLimitTestSuite) TestBasePathOf() {
+	s.Equal("/api", basePathOf("http://example.com/api/"))
+	s.Equal("", basePathOf("http://example.com"))
+	s.Equal("", basePathOf("://bad"))
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
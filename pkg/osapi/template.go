@@ -0,0 +1,104 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TemplateContext is the data a "template" file is rendered with on
+// the agent: {{ .Hostname }}, {{ .Vars.key }}, and {{ .Facts.key }}.
+type TemplateContext struct {
+	Hostname string
+	Vars     map[string]any
+	Facts    map[string]any
+}
+
+// NewTemplateContext builds the context an agent renders a template
+// with. A nil agent leaves Hostname and Facts empty.
+func NewTemplateContext(
+	vars map[string]any,
+	agent *Agent,
+) TemplateContext {
+	data := TemplateContext{Vars: vars}
+
+	if agent != nil {
+		data.Hostname = agent.Hostname
+		data.Facts = agent.Facts
+	}
+
+	return data
+}
+
+// RenderTemplate renders content as the agent would for a FileDeploy
+// with ContentType "template", using Go's text/template with its
+// standard functions. Pass the agent from Agent.Get to preview the
+// output for that host; missing keys render as "<no value>", matching
+// the agent.
+func RenderTemplate(
+	content string,
+	vars map[string]any,
+	agent *Agent,
+) (string, error) {
+	return renderTemplate(content, NewTemplateContext(vars, agent), "missingkey=default")
+}
+
+// ValidateTemplate parses content and renders it for agent, failing on
+// any variable or fact the template references but the context lacks.
+// With a nil agent, facts are unknown, so only parse and execution
+// errors are reported.
+func ValidateTemplate(
+	content string,
+	vars map[string]any,
+	agent *Agent,
+) error {
+	data := NewTemplateContext(vars, agent)
+	missing := "missingkey=error"
+
+	if agent == nil {
+		// Facts are unknown without an agent, so missing keys cannot
+		// be told apart from facts the agent would supply.
+		missing = "missingkey=default"
+	}
+
+	_, err := renderTemplate(content, data, missing)
+
+	return err
+}
+
+func renderTemplate(
+	content string,
+	data TemplateContext,
+	missingKey string,
+) (string, error) {
+	tmpl, err := template.New("template").Option(missingKey).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("render template: %w", err)
+	}
+
+	return b.String(), nil
+}
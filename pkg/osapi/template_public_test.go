@@ -0,0 +1,151 @@
+// Copyright (c) 2026 John Dewey
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to
+// deal in the Software without restriction, including without limitation the
+// rights to use, copy, modify, merge, publish, distribute, sublicense, and/or
+// sell copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+package osapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/osapi-io/osapi-sdk/pkg/osapi"
+)
+
+type TemplatePublicTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TemplatePublicTestSuite) agent() *osapi.Agent {
+	return &osapi.Agent{
+		Hostname: "web-01",
+		Facts:    map[string]any{"cpu_count": 4},
+	}
+}
+
+func (suite *TemplatePublicTestSuite) TestRenderTemplate() {
+	tests := []struct {
+		name         string
+		content      string
+		vars         map[string]any
+		agent        *osapi.Agent
+		validateFunc func(string, error)
+	}{
+		{
+			name:    "when rendering for an agent uses hostname, vars, and facts",
+			content: "# {{ .Hostname }}\nlisten = {{ .Vars.listen }}\nworkers = {{ .Facts.cpu_count }}\n",
+			vars:    map[string]any{"listen": "0.0.0.0:8080"},
+			agent:   suite.agent(),
+			validateFunc: func(out string, err error) {
+				suite.NoError(err)
+				suite.Equal("# web-01\nlisten = 0.0.0.0:8080\nworkers = 4\n", out)
+			},
+		},
+		{
+			name:    "when a key is missing renders no value like the agent",
+			content: "workers = {{ .Facts.cpu_count }}",
+			validateFunc: func(out string, err error) {
+				suite.NoError(err)
+				suite.Equal("workers = <no value>", out)
+			},
+		},
+		{
+			name:    "when template is malformed returns parse error",
+			content: "{{ .Vars.listen ",
+			validateFunc: func(out string, err error) {
+				suite.Empty(out)
+				suite.ErrorContains(err, "parse template")
+			},
+		},
+		{
+			name:    "when execution fails returns render error",
+			content: "{{ index .Vars.ports 5 }}",
+			vars:    map[string]any{"ports": []int{80}},
+			validateFunc: func(out string, err error) {
+				suite.Empty(out)
+				suite.ErrorContains(err, "render template")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(osapi.RenderTemplate(tc.content, tc.vars, tc.agent))
+		})
+	}
+}
+
+func (suite *TemplatePublicTestSuite) TestValidateTemplate() {
+	tests := []struct {
+		name         string
+		content      string
+		vars         map[string]any
+		agent        *osapi.Agent
+		validateFunc func(error)
+	}{
+		{
+			name:    "when every key resolves returns nil",
+			content: "{{ .Vars.listen }} {{ .Facts.cpu_count }}",
+			vars:    map[string]any{"listen": ":80"},
+			agent:   suite.agent(),
+			validateFunc: func(err error) {
+				suite.NoError(err)
+			},
+		},
+		{
+			name:    "when a fact is missing returns error",
+			content: "{{ .Facts.memory_total }}",
+			agent:   suite.agent(),
+			validateFunc: func(err error) {
+				suite.ErrorContains(err, "memory_total")
+			},
+		},
+		{
+			name:    "when a var is missing returns error",
+			content: "{{ .Vars.listen }}",
+			agent:   suite.agent(),
+			validateFunc: func(err error) {
+				suite.ErrorContains(err, "listen")
+			},
+		},
+		{
+			name:    "when agent is nil ignores missing keys",
+			content: "{{ .Facts.cpu_count }}",
+			validateFunc: func(err error) {
+				suite.NoError(err)
+			},
+		},
+		{
+			name:    "when agent is nil still reports parse errors",
+			content: "{{ if }}",
+			validateFunc: func(err error) {
+				suite.ErrorContains(err, "parse template")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			tc.validateFunc(osapi.ValidateTemplate(tc.content, tc.vars, tc.agent))
+		})
+	}
+}
+
+func TestTemplatePublicTestSuite(t *testing.T) {
+	suite.Run(t, new(TemplatePublicTestSuite))
+}